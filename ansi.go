@@ -0,0 +1,319 @@
+package notion
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type ANSIOption struct {
+	//NoColor write plain text without escape sequences
+	NoColor bool
+	//Width wrap lines at width of terminal, 0 is no wrapping
+	Width int
+}
+
+const ansiReset = "\x1b[0m"
+
+var ansiColors = map[string]string{
+	string(ColorGray):             "90",
+	string(ColorBrown):            "38;5;130",
+	string(ColorOrange):           "38;5;208",
+	string(ColorYellow):           "33",
+	string(ColorGreen):            "32",
+	string(ColorBlue):             "34",
+	string(ColorPurple):           "35",
+	string(ColorPink):             "38;5;205",
+	string(ColorRed):              "31",
+	string(ColorGrayBackground):   "100",
+	string(ColorBrownBackground):  "48;5;130",
+	string(ColorOrangeBackground): "48;5;208",
+	string(ColorYellowBackground): "43",
+	string(ColorGreenBackground):  "42",
+	string(ColorBlueBackground):   "44",
+	string(ColorPurpleBackground): "45",
+	string(ColorPinkBackground):   "48;5;205",
+	string(ColorRedBackground):    "41",
+}
+
+type ansiRenderer struct {
+	option ANSIOption
+}
+
+func newANSIRenderer(option *ANSIOption) *ansiRenderer {
+	renderer := &ansiRenderer{}
+	if option != nil {
+		renderer.option = *option
+	}
+
+	return renderer
+}
+
+// ANSIRichText render rich text with ANSI escape sequences for annotations and colors
+func ANSIRichText(text []RichText, option *ANSIOption) string {
+	renderer := newANSIRenderer(option)
+
+	return renderer.wrap(renderer.tokens(text, ""), "", "")
+}
+
+// ANSIBlocks render block tree for terminal, lists and toggles are indented and wrapped at Width
+func ANSIBlocks(nodes []*BlockNode, option *ANSIOption) string {
+	renderer := newANSIRenderer(option)
+
+	return strings.TrimRight(renderer.blocks(nodes, ""), "\n") + "\n"
+}
+
+// WrapANSI wrap text containing ANSI escape sequences at width, first line starts with prefix and others with indent
+func WrapANSI(Text string, Width int, Prefix, Indent string) string {
+	renderer := &ansiRenderer{option: ANSIOption{Width: Width}}
+
+	return renderer.wrap(splitANSITokens(Text, ""), Prefix, Indent)
+}
+
+func (renderer *ansiRenderer) sgr(codes ...string) string {
+	if renderer.option.NoColor || len(codes) == 0 {
+		return ""
+	}
+
+	return "\x1b[" + strings.Join(codes, ";") + "m"
+}
+
+func (renderer *ansiRenderer) blocks(nodes []*BlockNode, indent string) string {
+	b := &strings.Builder{}
+
+	number := 0
+	for _, node := range nodes {
+		if node.Block.Type() == TypeBlockNumberedListItem {
+			number++
+		} else {
+			number = 0
+		}
+
+		b.WriteString(renderer.block(node, indent, number))
+	}
+
+	return b.String()
+}
+
+func (renderer *ansiRenderer) block(node *BlockNode, indent string, number int) string {
+	block := node.Block
+
+	list := []RichText{}
+	if rtb, ok := richTextBlock(block); ok {
+		list, _ = rtb.ListText()
+	}
+
+	style := ""
+	marker := ""
+	switch block.Type() {
+	case TypeBlockHeading1:
+		style = renderer.sgr("1", "4")
+	case TypeBlockHeading2, TypeBlockHeading3:
+		style = renderer.sgr("1")
+	case TypeBlockBulletedListItem:
+		marker = "• "
+	case TypeBlockNumberedListItem:
+		marker = fmt.Sprintf("%d. ", number)
+	case TypeBlockTodo:
+		marker = "[ ] "
+		if todo, ok := block.(*BlockTodo); ok && todo.IsChecked() {
+			marker = "[x] "
+		}
+	case TypeBlockToggle:
+		marker = "▸ "
+	case TypeBlockChildPage:
+		marker = "▤ "
+		if j, ok := block.Json().GetJSON(TypeBlockChildPage); ok {
+			list = []RichText{*NewRichText(j.GetString("title"))}
+		}
+	case TypeBlockParagraph:
+	default:
+		return indent + renderer.sgr("2") + "[" + block.Type() + "]" + renderer.sgr("0") + "\n"
+	}
+
+	pad := strings.Repeat(" ", textWidth(marker))
+	text := renderer.wrap(renderer.tokens(list, style), indent+marker, indent+pad)
+
+	b := &strings.Builder{}
+	b.WriteString(text + "\n")
+
+	switch block.Type() {
+	case TypeBlockHeading1, TypeBlockHeading2, TypeBlockHeading3:
+		b.WriteString("\n")
+	case TypeBlockParagraph:
+		if len(node.Children) == 0 {
+			b.WriteString("\n")
+		}
+	}
+
+	if len(node.Children) > 0 {
+		childIndent := indent + pad
+		if len(pad) == 0 {
+			childIndent = indent + "  "
+		}
+		b.WriteString(renderer.blocks(node.Children, childIndent))
+	}
+
+	return b.String()
+}
+
+type ansiToken struct {
+	text  string
+	width int
+	//space and newline are separators between words
+	space   bool
+	newline bool
+}
+
+// tokens split rich text into styled words
+func (renderer *ansiRenderer) tokens(text []RichText, base string) []ansiToken {
+	tokens := []ansiToken{}
+
+	for i := range text {
+		rt := &text[i]
+		annotations := annotationsOf(rt)
+
+		content := rt.PlainText()
+		switch rt.Type() {
+		case "equation":
+			if equation, err := rt.GetEquation(); err == nil {
+				content = equation.Expression
+			}
+		case "text":
+			if t, err := rt.GetText(); err == nil {
+				content = t.Content
+			}
+		}
+
+		codes := []string{}
+		if annotations.Bold {
+			codes = append(codes, "1")
+		}
+		if annotations.Italic {
+			codes = append(codes, "3")
+		}
+		if annotations.Underline || len(rt.Href()) > 0 {
+			codes = append(codes, "4")
+		}
+		if annotations.Strikethrough {
+			codes = append(codes, "9")
+		}
+		if annotations.Code || rt.Type() == "equation" {
+			codes = append(codes, "36")
+		}
+		if rt.Type() == "mention" {
+			codes = append(codes, "1", "34")
+		}
+		if color, ok := ansiColors[annotations.Color]; ok {
+			codes = append(codes, color)
+		}
+
+		tokens = append(tokens, splitANSITokens(content, base+renderer.sgr(codes...))...)
+	}
+
+	return tokens
+}
+
+// splitANSITokens split text into words, spaces and newlines. every word is written with style
+func splitANSITokens(Text string, Style string) []ansiToken {
+	tokens := []ansiToken{}
+
+	word := &strings.Builder{}
+	flush := func() {
+		if word.Len() == 0 {
+			return
+		}
+		s := word.String()
+		word.Reset()
+
+		token := ansiToken{text: s, width: textWidth(s)}
+		if len(Style) > 0 {
+			token.text = Style + s + ansiReset
+		}
+		tokens = append(tokens, token)
+	}
+
+	for _, r := range Text {
+		switch r {
+		case '\n':
+			flush()
+			tokens = append(tokens, ansiToken{newline: true})
+		case ' ', '\t':
+			flush()
+			tokens = append(tokens, ansiToken{text: " ", width: 1, space: true})
+		default:
+			word.WriteRune(r)
+		}
+	}
+	flush()
+
+	return tokens
+}
+
+func (renderer *ansiRenderer) wrap(tokens []ansiToken, prefix, indent string) string {
+	b := &strings.Builder{}
+	b.WriteString(prefix)
+
+	width := textWidth(prefix)
+	words := 0
+	spaces := []ansiToken{}
+
+	for _, token := range tokens {
+		switch {
+		case token.newline:
+			b.WriteString("\n" + indent)
+			width = textWidth(indent)
+			words = 0
+			spaces = spaces[:0]
+		case token.space:
+			spaces = append(spaces, token)
+		default:
+			pending := 0
+			for _, space := range spaces {
+				pending += space.width
+			}
+
+			if renderer.option.Width > 0 && words > 0 && width+pending+token.width > renderer.option.Width {
+				b.WriteString("\n" + indent)
+				width = textWidth(indent)
+				spaces = spaces[:0]
+				pending = 0
+			}
+
+			for _, space := range spaces {
+				b.WriteString(space.text)
+			}
+			spaces = spaces[:0]
+
+			b.WriteString(token.text)
+			width += pending + token.width
+			words++
+		}
+	}
+
+	return b.String()
+}
+
+// textWidth return number of terminal columns of text without escape sequences
+func textWidth(s string) int {
+	width := 0
+	escape := false
+
+	for _, r := range s {
+		switch {
+		case escape:
+			if r == 'm' {
+				escape = false
+			}
+		case r == '\x1b':
+			escape = true
+		case unicode.Is(unicode.Mn, r):
+		case unicode.In(r, unicode.Hangul, unicode.Han, unicode.Hiragana, unicode.Katakana) || (r >= 0xFF00 && r <= 0xFF60):
+			width += 2
+		default:
+			width++
+		}
+	}
+
+	return width
+}
@@ -1,5 +1,7 @@
 package notion
 
+import "errors"
+
 type API interface {
 	ListAllUsers(pagination *PaginationRequest) (*PaginationResponse, error)
 	RetrieveUser(UserID string) (*User, error)
@@ -19,3 +21,24 @@ type API interface {
 
 	Version() string
 }
+
+// endpoints added after 2021-05-13 are optional interfaces, which API implements when its version provides them
+
+// BlockDeleteAPI API which can delete blocks
+type BlockDeleteAPI interface {
+	DeleteBlock(BlockID string) (Block, error)
+}
+
+// PropertyItemAPI API which can retrieve page property items, since PropertyItemVersion
+type PropertyItemAPI interface {
+	RetrievePagePropertyItem(PageID, PropertyID string, Pagination *PaginationRequest) (*PropertyItem, error)
+}
+
+// DatabaseWriteAPI API which can create databases and update their properties
+type DatabaseWriteAPI interface {
+	CreateDatabase(Parent *Parent, Title []RichText, Properties []Configuration) (*Database, error)
+	UpdateDatabase(DatabaseID string, Title []RichText, Properties []Configuration) (*Database, error)
+}
+
+// ErrUnsupportedVersion endpoint is not provided by version of API
+var ErrUnsupportedVersion = errors.New("unsupported on API version")
@@ -10,12 +10,15 @@ import (
 )
 
 type API struct {
-	token  string
-	client *http.Client
+	token   string
+	version string
+	client  *http.Client
 }
 
 type Option struct {
 	Timeout time.Duration
+	//Version value of Notion-Version header
+	Version string
 }
 
 func New(Token string, Opt *Option) *API {
@@ -26,13 +29,14 @@ func New(Token string, Opt *Option) *API {
 
 	if Opt != nil {
 		api.client.Timeout = Opt.Timeout
+		api.version = Opt.Version
 	}
 
 	return api
 }
 
 func (api *API) Version() string {
-	return ""
+	return api.version
 }
 
 func (api *API) baseURL() string {
@@ -54,9 +58,6 @@ func (api *API) prepareRequest(method string, url string, requestBody interface{
 		}
 
 		r = bytes.NewReader(jsonBody)
-
-		//Debug
-		fmt.Printf("[Json Body] ==> %s\n", jsonBody)
 	}
 
 	req, err := http.NewRequest(method, url, r)
@@ -84,6 +85,9 @@ func (api *API) doRequest(req *http.Request, responseBody interface{}) error {
 
 	if resp.StatusCode != http.StatusOK {
 		err := ReadError(resp.Body)
+		if apiError, ok := err.(*Error); ok {
+			apiError.retryAfter = resp.Header.Get("Retry-After")
+		}
 
 		return err
 	}
@@ -96,6 +100,7 @@ func (api *API) parseResponse(r io.Reader, v interface{}) error {
 	if d == nil {
 		return fmt.Errorf("decoder is nil")
 	}
+	d.UseNumber()
 
 	return d.Decode(v)
 }
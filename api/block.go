@@ -48,5 +48,31 @@ func (api *API) AppendBlockChildren(BlockID string, blocks []notion.Block) (noti
 		return nil, err
 	}
 
+	//since 2021-08-16 response is list of new children instead of parent block
+	if j.GetString("object") == "list" {
+		return notion.NewBlock(j), nil
+	}
+
 	return notion.AssignBlock(j)
 }
+
+func (api *API) DeleteBlock(BlockID string) (notion.Block, error) {
+	req, err := api.prepareRequest(http.MethodDelete,
+		fmt.Sprintf("%s/%s/blocks/%s",
+			api.baseURL(), api.contextVersion(), BlockID),
+		nil)
+	if err != nil {
+		return nil, err
+	}
+
+	j := notion.JSON{}
+	if err := api.doRequest(req, &j); err != nil {
+		return nil, err
+	}
+
+	if block, err := notion.AssignBlock(j); err == nil {
+		return block, nil
+	}
+
+	return notion.NewBlock(j), nil
+}
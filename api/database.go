@@ -38,11 +38,16 @@ func (api *API) QueryDatabase(DatabaseID string, Pagination *notion.PaginationRe
 	if len(Sorts) > 0 {
 		list := []notion.JSON{}
 		for _, sort := range Sorts {
-			list = append(list, notion.JSON{
-				"property":  sort.Property,
-				"timestamp": sort.Timestamp,
+			j := notion.JSON{
 				"direction": sort.Direction,
-			})
+			}
+			if len(sort.Property) > 0 {
+				j["property"] = sort.Property
+			}
+			if len(sort.Timestamp) > 0 {
+				j["timestamp"] = sort.Timestamp
+			}
+			list = append(list, j)
 		}
 		body.Set("sorts", list)
 	}
@@ -84,3 +89,65 @@ func (api *API) ListDatabases(Pagination *notion.PaginationRequest) (*notion.Pag
 
 	return p, nil
 }
+
+func (api *API) CreateDatabase(Parent *notion.Parent, Title []notion.RichText, Properties []notion.Configuration) (*notion.Database, error) {
+	if Parent == nil {
+		return nil, fmt.Errorf("Parent is Nil pointer")
+	}
+
+	body := databaseBody(Title, Properties)
+	body.Set("parent", Parent.JSON)
+	if body.Get("title") == nil {
+		body.Set("title", []notion.JSON{})
+	}
+
+	req, err := api.prepareRequest(http.MethodPost,
+		fmt.Sprintf("%s/%s/databases", api.baseURL(), api.contextVersion()),
+		body)
+	if err != nil {
+		return nil, err
+	}
+
+	database := &notion.Database{}
+	if err := api.doRequest(req, &database.JSON); err != nil {
+		return nil, err
+	}
+
+	return database, nil
+}
+
+func (api *API) UpdateDatabase(DatabaseID string, Title []notion.RichText, Properties []notion.Configuration) (*notion.Database, error) {
+	req, err := api.prepareRequest(http.MethodPatch,
+		fmt.Sprintf("%s/%s/databases/%s", api.baseURL(), api.contextVersion(), DatabaseID),
+		databaseBody(Title, Properties))
+	if err != nil {
+		return nil, err
+	}
+
+	database := &notion.Database{}
+	if err := api.doRequest(req, &database.JSON); err != nil {
+		return nil, err
+	}
+
+	return database, nil
+}
+
+func databaseBody(Title []notion.RichText, Properties []notion.Configuration) notion.JSON {
+	body := notion.JSON{}
+
+	if Title != nil {
+		title := []notion.JSON{}
+		for _, text := range Title {
+			title = append(title, text.JSON)
+		}
+		body.Set("title", title)
+	}
+
+	properties := notion.JSON{}
+	for _, property := range Properties {
+		properties.Set(property.Name(), property.Json())
+	}
+	body.Set("properties", properties)
+
+	return body
+}
@@ -11,12 +11,24 @@ type Error struct {
 	Status  int    `json:"status"`
 	Code    string `json:"code"`
 	Message string `json:"message"`
+
+	retryAfter string
 }
 
 func (err *Error) Error() string {
 	return fmt.Sprintf("[%d] %s: %s", err.Status, err.Code, err.Message)
 }
 
+// StatusCode return HTTP status of error
+func (err *Error) StatusCode() int {
+	return err.Status
+}
+
+// RetryAfter return Retry-After header of response, e.g. of rate limited request. empty when it is not sent
+func (err *Error) RetryAfter() string {
+	return err.retryAfter
+}
+
 func (err *Error) String() string {
 	return err.Error()
 }
@@ -34,6 +34,9 @@ func (api *API) CreatePage(Parent *notion.Parent, Properties []notion.Property,
 
 	properties := notion.JSON{}
 	for _, property := range Properties {
+		if notion.IsReadOnlyProperty(property) {
+			continue
+		}
 		properties.Set(property.Name(), property.Json())
 	}
 	body.Set("properties", properties)
@@ -66,6 +69,9 @@ func (api *API) UpdatePageProperties(PageID string, Properties ...notion.Propert
 
 	properties := notion.JSON{}
 	for _, property := range Properties {
+		if notion.IsReadOnlyProperty(property) {
+			continue
+		}
 		properties.Set(property.Name(), property.Json())
 	}
 	body.Set("properties", properties)
@@ -84,3 +90,25 @@ func (api *API) UpdatePageProperties(PageID string, Properties ...notion.Propert
 
 	return page, nil
 }
+
+func (api *API) RetrievePagePropertyItem(PageID, PropertyID string, Pagination *notion.PaginationRequest) (*notion.PropertyItem, error) {
+	query := ""
+	if Pagination != nil {
+		query = Pagination.QueryString()
+	}
+
+	req, err := api.prepareRequest(http.MethodGet,
+		fmt.Sprintf("%s/%s/pages/%s/properties/%s?%s",
+			api.baseURL(), api.contextVersion(), PageID, PropertyID, query),
+		nil)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &notion.PropertyItem{}
+	if err := api.doRequest(req, &item.JSON); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
@@ -3,11 +3,13 @@ package v20210513
 import (
 	"time"
 
+	"github.com/hunydev/notion"
 	"github.com/hunydev/notion/api"
 )
 
+// API endpoints of version 2021-05-13. endpoints added later, e.g. notion.BlockDeleteAPI, are not provided
 type API struct {
-	*api.API
+	notion.API
 }
 
 type Option struct {
@@ -19,11 +21,11 @@ func (api *API) Version() string {
 }
 
 func New(Token string, Opt *Option) *API {
-	api := &API{
-		API: api.New(Token, &api.Option{
-			Timeout: Opt.Timeout,
-		}),
-	}
+	a := &API{}
+	a.API = api.New(Token, &api.Option{
+		Timeout: Opt.Timeout,
+		Version: a.Version(),
+	})
 
-	return api
+	return a
 }
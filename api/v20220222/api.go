@@ -0,0 +1,134 @@
+package v20220222
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/hunydev/notion"
+	"github.com/hunydev/notion/api"
+)
+
+// API endpoints of version 2022-02-22, which has page property items, deleting blocks and writing databases.
+// rich text of blocks is "rich_text" in this version, it is converted from and to "text" of notion package
+type API struct {
+	*api.API
+}
+
+type Option struct {
+	Timeout time.Duration
+}
+
+func (api *API) Version() string {
+	return "2022-02-22"
+}
+
+func New(Token string, Opt *Option) *API {
+	a := &API{}
+	a.API = api.New(Token, &api.Option{
+		Timeout: Opt.Timeout,
+		Version: a.Version(),
+	})
+
+	return a
+}
+
+func (api *API) RetrieveBlockChildren(BlockID string, pagination *notion.PaginationRequest) (*notion.PaginationResponse, error) {
+	resp, err := api.API.RetrieveBlockChildren(BlockID, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range resp.Results {
+		renameText(result, "rich_text", "text")
+	}
+
+	return resp, nil
+}
+
+func (api *API) AppendBlockChildren(BlockID string, blocks []notion.Block) (notion.Block, error) {
+	block, err := api.API.AppendBlockChildren(BlockID, requestBlocks(blocks))
+	if err != nil {
+		return nil, err
+	}
+
+	//response is list of new children
+	j := block.Json()
+	if results, ok := j["results"].([]interface{}); ok {
+		for _, result := range results {
+			renameText(result, "rich_text", "text")
+		}
+	}
+	renameText(j, "rich_text", "text")
+
+	return block, nil
+}
+
+func (api *API) DeleteBlock(BlockID string) (notion.Block, error) {
+	block, err := api.API.DeleteBlock(BlockID)
+	if err != nil {
+		return nil, err
+	}
+	renameText(block.Json(), "rich_text", "text")
+
+	return block, nil
+}
+
+func (api *API) CreatePage(Parent *notion.Parent, Properties []notion.Property, Children ...notion.Block) (*notion.Page, error) {
+	return api.API.CreatePage(Parent, Properties, requestBlocks(Children)...)
+}
+
+// requestBlocks copy blocks with rich text of this version
+func requestBlocks(blocks []notion.Block) []notion.Block {
+	list := []notion.Block{}
+	for _, block := range blocks {
+		b, _ := json.Marshal(block.Json())
+		j := notion.JSON{}
+		d := json.NewDecoder(bytes.NewReader(b))
+		d.UseNumber()
+		d.Decode(&j)
+
+		renameText(j, "text", "rich_text")
+		list = append(list, notion.NewBlock(j))
+	}
+
+	return list
+}
+
+// renameText rename field of rich text of block and its nested children
+func renameText(block interface{}, from, to string) {
+	j, ok := jsonObject(block)
+	if !ok {
+		return
+	}
+
+	t, _ := j["type"].(string)
+	v, ok := jsonObject(j[t])
+	if !ok {
+		return
+	}
+
+	if text, ok := v[from]; ok {
+		if _, exists := v[to]; !exists {
+			v[to] = text
+			delete(v, from)
+		}
+	}
+
+	if children, ok := v["children"].([]interface{}); ok {
+		for _, child := range children {
+			renameText(child, from, to)
+		}
+	}
+}
+
+func jsonObject(v interface{}) (map[string]interface{}, bool) {
+	switch j := v.(type) {
+	case map[string]interface{}:
+		return j, true
+	case notion.JSON:
+		return j, true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,226 @@
+package notion
+
+import "fmt"
+
+// MaxAppendChildren maximum number of children in one AppendBlockChildren request
+const MaxAppendChildren = 100
+
+type AppendedBlock struct {
+	ID       string
+	ParentID string
+	Type     string
+	//Path index of block in the input tree, e.g. [2 0] is first child of third block
+	Path []int
+}
+
+type AppendTreeError struct {
+	//ParentID block which children could not be appended
+	ParentID string
+	//Path index of first block which was not written
+	Path []int
+	//Written blocks which were appended before failure
+	Written []AppendedBlock
+
+	Err error
+}
+
+func (err *AppendTreeError) Error() string {
+	return fmt.Sprintf("append children of '%s' at %v (%d blocks written): %v", err.ParentID, err.Path, len(err.Written), err.Err)
+}
+
+func (err *AppendTreeError) Unwrap() error {
+	return err.Err
+}
+
+// BlockChildren return nested children of block which are not appended yet
+func BlockChildren(block Block) []Block {
+	t := block.Type()
+
+	v, ok := block.Json().GetJSON(t)
+	if !ok {
+		return nil
+	}
+
+	list, ok := v.GetJSONList("children")
+	if !ok {
+		return nil
+	}
+
+	children := []Block{}
+	for _, j := range list {
+		child, err := AssignBlock(j)
+		if err != nil {
+			child = NewBlock(j)
+		}
+
+		children = append(children, child)
+	}
+
+	return children
+}
+
+// TreeBlocks convert nodes from FetchTree into blocks with nested children, which can be passed to AppendBlockTree
+func TreeBlocks(nodes []*BlockNode) []Block {
+	blocks := []Block{}
+
+	for _, node := range nodes {
+		j := appendableJSON(node.Block)
+
+		if len(node.Children) > 0 {
+			if v, ok := j.GetJSON(node.Block.Type()); ok {
+				children := []JSON{}
+				for _, child := range TreeBlocks(node.Children) {
+					children = append(children, child.Json())
+				}
+				v["children"] = children
+			}
+		}
+
+		block, err := AssignBlock(j)
+		if err != nil {
+			block = NewBlock(j)
+		}
+		blocks = append(blocks, block)
+	}
+
+	return blocks
+}
+
+// appendableJSON copy block without read-only fields and nested children
+func appendableJSON(block Block) JSON {
+	t := block.Type()
+
+	j := JSON{
+		"object": "block",
+		"type":   t,
+	}
+
+	if v, ok := block.Json().GetJSON(t); ok {
+		vv := JSON{}
+		vv.Marshal(v)
+		delete(vv, "children")
+		j[t] = vv
+	}
+
+	return j
+}
+
+// AppendBlockTree append blocks with arbitrarily deep children. each level is split into batches of MaxAppendChildren,
+// then children are appended to the IDs of written blocks in order. on failure the error is *AppendTreeError
+func (notion *Notion) AppendBlockTree(BlockID string, Blocks []Block) ([]AppendedBlock, error) {
+	if notion.invalid() {
+		return nil, fmt.Errorf("Nil pointer API Implementation")
+	}
+
+	written := []AppendedBlock{}
+
+	if err := notion.appendBlockTree(BlockID, Blocks, []int{}, &written); err != nil {
+		err.Written = written
+		return written, err
+	}
+
+	return written, nil
+}
+
+func (notion *Notion) appendBlockTree(ParentID string, Blocks []Block, Path []int, written *[]AppendedBlock) *AppendTreeError {
+	if len(Blocks) == 0 {
+		return nil
+	}
+
+	count := 0
+	var failure error
+	created := []Block{}
+	listed := true
+
+	for start := 0; start < len(Blocks); start += MaxAppendChildren {
+		end := start + MaxAppendChildren
+		if end > len(Blocks) {
+			end = len(Blocks)
+		}
+
+		batch := []Block{}
+		for _, block := range Blocks[start:end] {
+			batch = append(batch, NewBlock(appendableJSON(block)))
+		}
+
+		resp, err := notion.api.AppendBlockChildren(ParentID, batch)
+		if err != nil {
+			failure = err
+			break
+		}
+		count = end
+
+		blocks, ok := appendedChildren(resp, len(batch))
+		listed = listed && ok
+		created = append(created, blocks...)
+	}
+
+	if count > 0 && !listed {
+		//2021-05-13 responds parent block, so IDs of new blocks are read from the tail of children
+		children, err := notion.RetrieveAllBlockChildren(ParentID, MaxAppendChildren)
+		if err != nil {
+			return &AppendTreeError{ParentID: ParentID, Path: childPath(Path, 0), Err: err}
+		}
+		if len(children) < count {
+			return &AppendTreeError{ParentID: ParentID, Path: childPath(Path, 0), Err: fmt.Errorf("expected %d children, found %d", count, len(children))}
+		}
+		created = children[len(children)-count:]
+	}
+
+	ids := []string{}
+	for i, child := range created {
+		if child.Type() != Blocks[i].Type() {
+			return &AppendTreeError{ParentID: ParentID, Path: childPath(Path, i), Err: fmt.Errorf("children of '%s' were changed during append", ParentID)}
+		}
+
+		ids = append(ids, child.ID())
+		*written = append(*written, AppendedBlock{
+			ID:       child.ID(),
+			ParentID: ParentID,
+			Type:     child.Type(),
+			Path:     childPath(Path, i),
+		})
+	}
+
+	if failure != nil {
+		return &AppendTreeError{ParentID: ParentID, Path: childPath(Path, count), Err: failure}
+	}
+
+	for i, block := range Blocks {
+		if err := notion.appendBlockTree(ids[i], BlockChildren(block), childPath(Path, i), written); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// appendedChildren read new blocks from response of AppendBlockChildren, which is list of them since version 2021-08-16
+func appendedChildren(resp Block, count int) ([]Block, bool) {
+	if resp == nil || resp.Json().GetBool("has_more") {
+		return nil, false
+	}
+
+	results, ok := resp.Json().GetJSONList("results")
+	if !ok || len(results) < count {
+		return nil, false
+	}
+
+	blocks := []Block{}
+	for _, j := range results[len(results)-count:] {
+		block, err := AssignBlock(j)
+		if err != nil {
+			block = NewBlock(j)
+		}
+		blocks = append(blocks, block)
+	}
+
+	return blocks, true
+}
+
+func childPath(Path []int, index int) []int {
+	path := make([]int, len(Path), len(Path)+1)
+	copy(path, Path)
+
+	return append(path, index)
+}
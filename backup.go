@@ -0,0 +1,458 @@
+package notion
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// BackupIndexName file of index in backup archive
+const BackupIndexName = "index.json"
+
+// BackupIndex describe contents of backup archive
+type BackupIndex struct {
+	//APIVersion version of API which backup is made with
+	APIVersion  string `json:"api_version"`
+	CreatedTime string `json:"created_time"`
+	//Since backup is incremental, objects edited before Since have no file in archive
+	Since string `json:"since,omitempty"`
+	//Users file of list of users
+	Users   string        `json:"users"`
+	Entries []BackupEntry `json:"entries"`
+}
+
+// BackupEntry page or database found in workspace
+type BackupEntry struct {
+	Object string `json:"object"`
+	ID     string `json:"id"`
+	//ParentType page_id, database_id or workspace
+	ParentType     string `json:"parent_type"`
+	ParentID       string `json:"parent_id,omitempty"`
+	Title          string `json:"title"`
+	LastEditedTime string `json:"last_edited_time"`
+	//Path file of object in archive, empty if object is not edited since Since of incremental backup
+	Path string `json:"path,omitempty"`
+	//Error object could not be backed up. page without Path is written by next incremental backup
+	Error string `json:"error,omitempty"`
+}
+
+// BackupPage file of page, children of block are nested in "children" of block.
+// blocks of child pages and child databases are not nested, they are files of their own
+type BackupPage struct {
+	Page   JSON   `json:"page"`
+	Blocks []JSON `json:"blocks"`
+}
+
+type BackupOption struct {
+	//Since incremental backup, only pages and databases edited at or after Since are written. zero is full backup.
+	//last_edited_time is rounded down to minute, so Since is truncated to minute
+	Since time.Time
+	//Include IDs of objects written regardless of Since, e.g. objects which failed in previous backup
+	Include []string
+	//CompleteProperties retrieve values of properties which may be truncated in page object. see CompletePageProperties,
+	//it needs PropertyItemAPI
+	CompleteProperties bool
+	//Concurrency of FetchTree, default 4
+	Concurrency int
+	//Progress called after each page or database is found
+	Progress func(entry BackupEntry)
+}
+
+type backupWriter struct {
+	notion *Notion
+	option BackupOption
+	zip    *zip.Writer
+	index  *BackupIndex
+
+	queue   []JSON
+	seen    map[string]bool
+	include map[string]bool
+}
+
+// Backup write pages, databases with their rows and users of workspace to w as zip archive of JSON files.
+// objects are found by Search, ListDatabases, QueryDatabase and child pages and databases in content of pages.
+// in incremental backup, content of unchanged pages is not traversed, so pages found only there are not found.
+// object which fails is recorded with Error in index and backup continues
+func (notion *Notion) Backup(w io.Writer, Option *BackupOption) (*BackupIndex, error) {
+	if notion.invalid() {
+		return nil, fmt.Errorf("Nil pointer API Implementation")
+	}
+
+	backup := &backupWriter{
+		notion: notion,
+		zip:    zip.NewWriter(w),
+		index: &BackupIndex{
+			APIVersion:  notion.APIVersion(),
+			CreatedTime: TimeFormat(time.Now().UTC()),
+			Users:       "users.json",
+			Entries:     []BackupEntry{},
+		},
+		seen:    map[string]bool{},
+		include: map[string]bool{},
+	}
+	if Option != nil {
+		backup.option = *Option
+	}
+	if backup.option.CompleteProperties && !notion.SupportsPropertyItems() {
+		return nil, notion.unsupported("page property items")
+	}
+	if !backup.option.Since.IsZero() {
+		backup.option.Since = backup.option.Since.UTC().Truncate(time.Minute)
+		backup.index.Since = TimeFormat(backup.option.Since)
+	}
+	for _, id := range backup.option.Include {
+		backup.include[id] = true
+	}
+
+	if err := backup.run(); err != nil {
+		backup.zip.Close()
+		return nil, err
+	}
+
+	if err := backup.write(BackupIndexName, backup.index); err != nil {
+		return nil, err
+	}
+	if err := backup.zip.Close(); err != nil {
+		return nil, err
+	}
+
+	return backup.index, nil
+}
+
+func (backup *backupWriter) run() error {
+	users, err := listAll(backup.notion.ListAllUsers)
+	if err != nil {
+		return fmt.Errorf("users: %w", err)
+	}
+	if err := backup.write(backup.index.Users, users); err != nil {
+		return err
+	}
+
+	databases, err := listAll(backup.notion.ListDatabases)
+	if err != nil {
+		return fmt.Errorf("databases: %w", err)
+	}
+	backup.queue = append(backup.queue, databases...)
+
+	for _, object := range []Object{ObjectDatabase, ObjectPage} {
+		results, err := listAll(func(pagination *PaginationRequest) (*PaginationResponse, error) {
+			return backup.notion.Search("", pagination, object, nil)
+		})
+		if err != nil {
+			return fmt.Errorf("search: %w", err)
+		}
+		backup.queue = append(backup.queue, results...)
+	}
+
+	for len(backup.queue) > 0 {
+		j := backup.queue[0]
+		backup.queue = backup.queue[1:]
+
+		id := j.GetString("id")
+		if backup.seen[id] {
+			continue
+		}
+		backup.seen[id] = true
+
+		var entry BackupEntry
+		var err error
+		switch j.GetString("object") {
+		case ObjectDatabase.String():
+			entry, err = backup.database(&Database{JSON: j})
+		case ObjectPage.String():
+			entry, err = backup.page(&Page{JSON: j})
+		default:
+			continue
+		}
+		if err != nil {
+			var objectErr *backupObjectError
+			if !errors.As(err, &objectErr) {
+				return err
+			}
+			entry.Error = objectErr.err.Error()
+		}
+		backup.add(entry)
+	}
+
+	return nil
+}
+
+// backupObjectError error of one object, which is recorded in index instead of stopping backup
+type backupObjectError struct {
+	err error
+}
+
+func (e *backupObjectError) Error() string {
+	return e.err.Error()
+}
+
+// changed check object is written in this backup
+func (backup *backupWriter) changed(ID, LastEditedTime string) bool {
+	if backup.option.Since.IsZero() || backup.include[ID] {
+		return true
+	}
+
+	t, err := ParseTime(LastEditedTime)
+	if err != nil {
+		return true
+	}
+
+	return !t.Before(backup.option.Since)
+}
+
+func (backup *backupWriter) database(database *Database) (BackupEntry, error) {
+	entry := BackupEntry{
+		Object:         ObjectDatabase.String(),
+		ID:             database.ID(),
+		Title:          PlainText(database.Title()),
+		LastEditedTime: database.LastEditedTime(),
+	}
+	entry.ParentType, entry.ParentID = backupParent(database.JSON)
+
+	if backup.changed(entry.ID, entry.LastEditedTime) {
+		entry.Path = "databases/" + entry.ID + ".json"
+		if err := backup.write(entry.Path, database.JSON); err != nil {
+			return entry, err
+		}
+	}
+
+	// rows are checked one by one, editing row does not change database
+	rows, err := listAll(func(pagination *PaginationRequest) (*PaginationResponse, error) {
+		return backup.notion.QueryDatabase(database.ID(), pagination, nil, nil)
+	})
+	if err != nil {
+		return entry, &backupObjectError{fmt.Errorf("rows: %w", err)}
+	}
+	backup.queue = append(backup.queue, rows...)
+
+	return entry, nil
+}
+
+func (backup *backupWriter) page(page *Page) (BackupEntry, error) {
+	entry := BackupEntry{
+		Object:         ObjectPage.String(),
+		ID:             page.ID(),
+		Title:          PlainText(page.Title()),
+		LastEditedTime: page.LastEditedTime(),
+	}
+	entry.ParentType, entry.ParentID = backupParent(page.JSON)
+
+	if !backup.changed(entry.ID, entry.LastEditedTime) {
+		return entry, nil
+	}
+
+	if backup.option.CompleteProperties {
+		properties, err := backup.notion.CompletePageProperties(page)
+		if err != nil {
+			return entry, &backupObjectError{err}
+		}
+		values := JSON{}
+		for _, property := range properties {
+			values[property.Name()] = property.Json()
+		}
+		page.JSON["properties"] = values
+	}
+
+	nodes, err := backup.notion.FetchTree(page.ID(), &FetchTreeOption{
+		Concurrency: backup.option.Concurrency,
+		PageSize:    100,
+		SkipTypes:   []string{TypeBlockChildPage, TypeBlockChildDatabase},
+	})
+	if err != nil {
+		return entry, &backupObjectError{err}
+	}
+
+	err = nil
+	WalkTree(nodes, func(node *BlockNode) bool {
+		if err != nil {
+			return false
+		}
+
+		switch node.Block.Type() {
+		case TypeBlockChildPage:
+			if !backup.seen[node.Block.ID()] {
+				var child *Page
+				if child, err = backup.notion.RetrievePage(node.Block.ID()); err == nil {
+					backup.queue = append(backup.queue, child.JSON)
+				}
+			}
+		case TypeBlockChildDatabase:
+			if !backup.seen[node.Block.ID()] {
+				var child *Database
+				if child, err = backup.notion.RetrieveDatabase(node.Block.ID()); err == nil {
+					backup.queue = append(backup.queue, child.JSON)
+				}
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return entry, &backupObjectError{err}
+	}
+
+	entry.Path = "pages/" + entry.ID + ".json"
+	if err := backup.write(entry.Path, &BackupPage{Page: page.JSON, Blocks: treeJSON(nodes)}); err != nil {
+		return entry, err
+	}
+
+	return entry, nil
+}
+
+func (backup *backupWriter) add(entry BackupEntry) {
+	backup.index.Entries = append(backup.index.Entries, entry)
+
+	if backup.option.Progress != nil {
+		backup.option.Progress(entry)
+	}
+}
+
+func (backup *backupWriter) write(name string, v interface{}) error {
+	w, err := backup.zip.Create(name)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(v)
+}
+
+func backupParent(j JSON) (string, string) {
+	parent, ok := j.GetJSON("parent")
+	if !ok {
+		return "", ""
+	}
+
+	t := parent.GetString("type")
+	if t == TypeParentWorkspace {
+		return t, ""
+	}
+
+	return t, parent.GetString(t)
+}
+
+// treeJSON JSON of blocks with children nested in "children"
+func treeJSON(nodes []*BlockNode) []JSON {
+	list := make([]JSON, 0, len(nodes))
+
+	for _, node := range nodes {
+		j := JSON{}
+		for k, v := range node.Block.Json() {
+			j[k] = v
+		}
+		if len(node.Children) > 0 {
+			j["children"] = treeJSON(node.Children)
+		}
+		list = append(list, j)
+	}
+
+	return list
+}
+
+// listAll retrieve results of all pages of list
+func listAll(fetch func(pagination *PaginationRequest) (*PaginationResponse, error)) ([]JSON, error) {
+	results := []JSON{}
+	pagination := &PaginationRequest{PageSize: 100}
+
+	for {
+		resp, err := fetch(pagination)
+		if err != nil {
+			return nil, err
+		}
+
+		list := []JSON{}
+		if err := resp.Unmarshal(&list); err != nil {
+			return nil, err
+		}
+		results = append(results, list...)
+
+		if !resp.HasMore || len(resp.NextCursor) == 0 {
+			return results, nil
+		}
+		pagination.StartCursor = resp.NextCursor
+	}
+}
+
+// BackupArchive backup archive opened for reading
+type BackupArchive struct {
+	Index *BackupIndex
+
+	zip *zip.Reader
+}
+
+// OpenBackup read index of backup archive
+func OpenBackup(r io.ReaderAt, size int64) (*BackupArchive, error) {
+	reader, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := &BackupArchive{zip: reader, Index: &BackupIndex{}}
+	if err := archive.read(BackupIndexName, archive.Index); err != nil {
+		return nil, err
+	}
+
+	return archive, nil
+}
+
+func (archive *BackupArchive) read(name string, v interface{}) error {
+	f, err := archive.zip.Open(name)
+	if err != nil {
+		return fmt.Errorf("backup: %w", err)
+	}
+	defer f.Close()
+
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return err
+	}
+
+	return decodeJSON(b, v)
+}
+
+// Users return users of workspace at time of backup
+func (archive *BackupArchive) Users() ([]User, error) {
+	list := []JSON{}
+	if err := archive.read(archive.Index.Users, &list); err != nil {
+		return nil, err
+	}
+
+	users := make([]User, 0, len(list))
+	for _, j := range list {
+		users = append(users, User{ID: j.GetString("id"), JSON: j})
+	}
+
+	return users, nil
+}
+
+// Database return database of entry, entry without Path is not in this archive
+func (archive *BackupArchive) Database(Entry BackupEntry) (*Database, error) {
+	if len(Entry.Path) == 0 {
+		return nil, fmt.Errorf("database '%s' is not in this backup", Entry.ID)
+	}
+
+	database := &Database{JSON: JSON{}}
+	if err := archive.read(Entry.Path, &database.JSON); err != nil {
+		return nil, err
+	}
+
+	return database, nil
+}
+
+// Page return page of entry with its blocks, entry without Path is not in this archive
+func (archive *BackupArchive) Page(Entry BackupEntry) (*BackupPage, error) {
+	if len(Entry.Path) == 0 {
+		return nil, fmt.Errorf("page '%s' is not in this backup", Entry.ID)
+	}
+
+	page := &BackupPage{}
+	if err := archive.read(Entry.Path, page); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
@@ -15,6 +15,8 @@ const (
 	TypeBlockTodo             = "to_do"
 	TypeBlockToggle           = "toggle"
 	TypeBlockChildPage        = "child_page"
+	TypeBlockImage            = "image"
+	TypeBlockChildDatabase    = "child_database"
 	TypeBlockUnsupported      = "unsupported"
 )
 
@@ -55,6 +57,10 @@ func AssignBlock(json JSON) (Block, error) {
 		block = &BlockToggle{&ChildrenBlock{&RichTextBlock{&CustomBlock{id: json.GetString("id"), JSON: json}}}}
 	case TypeBlockChildPage:
 		block = &BlockChildPage{&CustomBlock{id: json.GetString("id"), JSON: json}}
+	case TypeBlockImage:
+		block = &BlockImage{&CustomBlock{id: json.GetString("id"), JSON: json}}
+	case TypeBlockChildDatabase:
+		block = &BlockChildDatabase{&CustomBlock{id: json.GetString("id"), JSON: json}}
 	case TypeBlockUnsupported:
 		block = &BlockUnsupported{&CustomBlock{id: json.GetString("id"), JSON: json}}
 	default:
@@ -134,7 +140,7 @@ func (block *RichTextBlock) AddText(text []RichText) error {
 		return fmt.Errorf("not found text field")
 	}
 
-	for _, t := range text {
+	for _, t := range SplitRichText(text) {
 		v.Append("text", t.JSON)
 	}
 
@@ -204,8 +210,12 @@ func newChildrenBlock(Type string, Text []RichText, Children ...Block) *Children
 		RichTextBlock: base,
 	}
 
-	block.JSON.Set("children", []JSON{})
-	block.AddChildren(Children)
+	if v, ok := block.JSON.GetJSON(Type); ok {
+		v["children"] = []JSON{}
+	}
+	if err := block.AddChildren(Children); err != nil {
+		log.Print(err)
+	}
 
 	return block
 }
@@ -227,7 +237,7 @@ func (block *ChildrenBlock) AddChildren(children []Block) error {
 	}
 
 	for _, t := range children {
-		v.Append("text", t.Json())
+		v.Append("children", t.Json())
 	}
 
 	return nil
@@ -351,8 +361,8 @@ func (block *BlockNumberedListItem) Interface() interface{} {
 }
 
 func NewBlockNumberedListItem(Text []RichText, Children ...Block) Block {
-	block := &BlockBulletedListItem{
-		ChildrenBlock: newChildrenBlock("bulleted_list_item", Text, Children...),
+	block := &BlockNumberedListItem{
+		ChildrenBlock: newChildrenBlock("numbered_list_item", Text, Children...),
 	}
 
 	return block
@@ -367,7 +377,7 @@ func (block *BlockTodo) Interface() interface{} {
 }
 
 func NewBlockTodo(Checked bool, Text []RichText, Children ...Block) Block {
-	block := &BlockBulletedListItem{
+	block := &BlockTodo{
 		ChildrenBlock: newChildrenBlock("to_do", Text, Children...),
 	}
 	if j, ok := block.JSON.GetJSON("to_do"); ok {
@@ -406,7 +416,7 @@ func (block *BlockToggle) Interface() interface{} {
 }
 
 func NewBlockToggle(Text []RichText, Children ...Block) Block {
-	block := &BlockBulletedListItem{
+	block := &BlockToggle{
 		ChildrenBlock: newChildrenBlock("toggle", Text, Children...),
 	}
 
@@ -438,6 +448,76 @@ func NewBlockChildPage(PageID string) Block {
 	return block
 }
 
+// BlockImage image of external URL or file uploaded to Notion, URL of uploaded file expires
+type BlockImage struct {
+	*CustomBlock
+}
+
+func (block *BlockImage) Interface() interface{} {
+	return block
+}
+
+func NewBlockImage(URL string) Block {
+	block := &BlockImage{
+		CustomBlock: &CustomBlock{
+			id: "",
+			JSON: JSON{
+				"object": "block",
+				"type":   "image",
+				"image": JSON{
+					"type": "external",
+					"external": JSON{
+						"url": URL,
+					},
+				},
+			},
+		},
+	}
+
+	return block
+}
+
+// IsExternal check image is linked from external URL
+func (block *BlockImage) IsExternal() bool {
+	j, _ := block.JSON.GetJSON(TypeBlockImage)
+
+	return j.GetString("type") == "external"
+}
+
+func (block *BlockImage) URL() string {
+	j, _ := block.JSON.GetJSON(TypeBlockImage)
+	file, _ := j.GetJSON(j.GetString("type"))
+
+	return file.GetString("url")
+}
+
+func (block *BlockImage) Caption() []RichText {
+	list := []RichText{}
+
+	j, _ := block.JSON.GetJSON(TypeBlockImage)
+	caption, _ := j.GetJSONList("caption")
+	for _, jj := range caption {
+		list = append(list, RichText{JSON: jj})
+	}
+
+	return list
+}
+
+// BlockChildDatabase database in page, ID of block is ID of database. it can not be appended
+type BlockChildDatabase struct {
+	*CustomBlock
+}
+
+func (block *BlockChildDatabase) Interface() interface{} {
+	return block
+}
+
+func (block *BlockChildDatabase) Title() string {
+	j, _ := block.JSON.GetJSON(TypeBlockChildDatabase)
+
+	return j.GetString("title")
+}
+
 type BlockUnsupported struct {
 	*CustomBlock
 }
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hunydev/notion"
+)
+
+func backup(c *cli, args []string) error {
+	fs := c.flags("backup")
+	to := fs.String("to", "", "file of backup archive (zip), default is notion-backup-<time>.zip")
+	incremental := fs.String("incremental", "", "archive of previous backup, only pages and databases edited since it are written")
+	since := fs.String("since", "", "write only pages and databases edited since timestamp")
+	complete := fs.Bool("complete-properties", false, "retrieve values of properties truncated in page object, one request per property. needs API version "+notion.PropertyItemVersion)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 || (len(*incremental) > 0 && len(*since) > 0) {
+		return errUsage
+	}
+	if len(*to) == 0 {
+		*to = fmt.Sprintf("notion-backup-%s.zip", backupTime(time.Now()))
+	}
+
+	option := &notion.BackupOption{
+		CompleteProperties: *complete,
+		Progress: func(entry notion.BackupEntry) {
+			if len(entry.Error) > 0 {
+				fmt.Fprintf(c.stderr, "notion backup: %s %s: %s\n", entry.Object, entry.ID, entry.Error)
+			} else if len(entry.Path) > 0 {
+				fmt.Fprintf(c.stderr, "%s %s %s\n", entry.Object, entry.ID, entry.Title)
+			}
+		},
+	}
+
+	switch {
+	case len(*incremental) > 0:
+		archive, close, err := openBackup(*incremental)
+		if err != nil {
+			return err
+		}
+		close()
+
+		t, err := notion.ParseTime(archive.Index.CreatedTime)
+		if err != nil {
+			return fmt.Errorf("%s: %w", *incremental, err)
+		}
+		option.Since = t
+		// objects which failed are written again even if not edited since
+		for _, entry := range archive.Index.Entries {
+			if len(entry.Error) > 0 {
+				option.Include = append(option.Include, entry.ID)
+			}
+		}
+	case len(*since) > 0:
+		t, err := notion.ParseTime(*since)
+		if err != nil {
+			return err
+		}
+		option.Since = t
+	}
+
+	tmp := *to + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	index, err := c.nt.Backup(f, option)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, *to); err != nil {
+		return err
+	}
+
+	t := newTable("OBJECT", "ID", "STATUS", "TITLE")
+	failed := 0
+	for _, entry := range index.Entries {
+		status := "written"
+		if len(entry.Error) > 0 {
+			status = "failed"
+			failed++
+		} else if len(entry.Path) == 0 {
+			status = "unchanged"
+		}
+		t.add(entry.Object, entry.ID, status, entry.Title)
+	}
+
+	if err := c.print(index, t); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d objects failed, see errors in %s of %s", failed, notion.BackupIndexName, *to)
+	}
+
+	return nil
+}
+
+// openBackup open backup archive of file, close file after use
+func openBackup(name string) (*notion.BackupArchive, func() error, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+
+	archive, err := notion.OpenBackup(f, info.Size())
+	if err != nil {
+		f.Close()
+		return nil, nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return archive, f.Close, nil
+}
+
+// backupTime time in name of archive
+func backupTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+func restore(c *cli, args []string) error {
+	fs := c.flags("restore")
+	parent := fs.String("parent", "", "ID of page to restore in")
+	report := fs.String("report", "", "file to write mapping of old IDs to new IDs as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 || len(*parent) == 0 {
+		return errUsage
+	}
+
+	archives := []*notion.BackupArchive{}
+	for _, name := range fs.Args() {
+		archive, close, err := openBackup(name)
+		if err != nil {
+			return err
+		}
+		defer close()
+		archives = append(archives, archive)
+	}
+
+	result, err := c.nt.Restore(archives, *parent, &notion.RestoreOption{
+		Progress: func(entry *notion.RestoreEntry) {
+			fmt.Fprintf(c.stderr, "%s %s -> %s %s\n", entry.Object, entry.OldID, entry.NewID, entry.Title)
+		},
+	})
+	if result != nil && len(*report) > 0 {
+		b, jsonErr := json.MarshalIndent(result, "", "  ")
+		if jsonErr != nil {
+			return jsonErr
+		}
+		if writeErr := os.WriteFile(*report, b, 0644); writeErr != nil {
+			return writeErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	t := newTable("OBJECT", "OLD ID", "NEW ID", "TITLE", "WARNINGS")
+	for _, entry := range result.Entries {
+		t.add(entry.Object, entry.OldID, entry.NewID, entry.Title, strings.Join(entry.Warnings, "; "))
+	}
+
+	return c.print(result, t)
+}
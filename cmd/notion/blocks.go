@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hunydev/notion"
+)
+
+func blocksList(c *cli, args []string) error {
+	fs := c.flags("blocks list")
+	recursive := fs.Bool("recursive", false, "list children of children")
+	depth := fs.Int("depth", 0, "maximum depth of --recursive, 0 is unlimited")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errUsage
+	}
+
+	var nodes []*notion.BlockNode
+	if *recursive {
+		tree, err := c.nt.FetchTree(fs.Arg(0), &notion.FetchTreeOption{MaxDepth: *depth})
+		if err != nil {
+			return err
+		}
+		nodes = tree
+	} else {
+		blocks, err := c.nt.RetrieveAllBlockChildren(fs.Arg(0), 100)
+		if err != nil {
+			return err
+		}
+		for _, block := range blocks {
+			nodes = append(nodes, &notion.BlockNode{Block: block})
+		}
+	}
+
+	t := newTable("ID", "TYPE", "CHILDREN", "TEXT")
+	notion.WalkTree(nodes, func(node *notion.BlockNode) bool {
+		t.add(node.Block.ID(), node.Block.Type(), fmt.Sprint(node.Block.HasChildren()),
+			strings.Repeat("  ", node.Depth)+notion.BlockPlainText(node.Block))
+		return true
+	})
+
+	return c.print(nodeJSONs(nodes), t)
+}
+
+func blocksAppend(c *cli, args []string) error {
+	fs := c.flags("blocks append")
+	markdown := fs.String("markdown", "", "file of blocks in Markdown, - is standard input")
+	texts := &stringList{}
+	fs.Var(texts, "text", "paragraph of inline markup, see notion.ParseRichText (repeatable)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || (len(*markdown) == 0 && len(*texts) == 0) {
+		return errUsage
+	}
+
+	blocks := []notion.Block{}
+	if len(*markdown) > 0 {
+		text, err := c.readInput(*markdown)
+		if err != nil {
+			return err
+		}
+		blocks = append(blocks, notion.ParseMarkdown(text)...)
+	}
+	for _, text := range *texts {
+		blocks = append(blocks, notion.NewBlockParagraph(notion.ParseRichText(text, nil)))
+	}
+
+	appended, err := c.nt.AppendBlockTree(fs.Arg(0), blocks)
+	if err != nil {
+		return err
+	}
+
+	t := newTable("ID", "TYPE", "PARENT")
+	for _, block := range appended {
+		t.add(block.ID, block.Type, block.ParentID)
+	}
+
+	return c.print(appended, t)
+}
+
+// nodeJSONs JSON of blocks, children of block are set in "children"
+func nodeJSONs(nodes []*notion.BlockNode) []notion.JSON {
+	list := make([]notion.JSON, 0, len(nodes))
+
+	for _, node := range nodes {
+		j := notion.JSON{}
+		for k, v := range node.Block.Json() {
+			j[k] = v
+		}
+		if len(node.Children) > 0 {
+			j["children"] = nodeJSONs(node.Children)
+		}
+		list = append(list, j)
+	}
+
+	return list
+}
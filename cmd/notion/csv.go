@@ -0,0 +1,313 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hunydev/notion"
+)
+
+// csvCodec convert between cells of CSV and properties of database.
+// lists are joined by ", ", dates are ISO 8601 start or start/end, people are emails or names
+type csvCodec struct {
+	c      *cli
+	schema map[string]notion.Configuration
+	//titleName name of title property
+	titleName string
+	//names people are names instead of emails
+	names bool
+	//markdown title and rich text are Markdown instead of plain text
+	markdown bool
+
+	users []notion.User
+}
+
+func newCSVCodec(c *cli, database *notion.Database) *csvCodec {
+	codec := &csvCodec{c: c, schema: map[string]notion.Configuration{}}
+
+	for _, configuration := range database.Properties() {
+		codec.schema[configuration.Name()] = configuration
+		if configuration.Type() == notion.TypePropertyTitle {
+			codec.titleName = configuration.Name()
+		}
+	}
+
+	return codec
+}
+
+// writable check value of property can be written from cell
+func (codec *csvCodec) writable(name string) bool {
+	configuration, ok := codec.schema[name]
+	if !ok {
+		return false
+	}
+
+	switch configuration.Type() {
+	case notion.TypePropertyTitle, notion.TypePropertyRichText, notion.TypePropertyNumber, notion.TypePropertySelect,
+		notion.TypePropertyMultiSelect, notion.TypePropertyDate, notion.TypePropertyCheckbox, notion.TypePropertyURL,
+		notion.TypePropertyEmail, notion.TypePropertyPhoneNumber, notion.TypePropertyPeople, notion.TypePropertyRelation:
+		return true
+	}
+
+	return false
+}
+
+// cell text of property
+func (codec *csvCodec) cell(property notion.Property) string {
+	switch v := codec.value(property).(type) {
+	case nil:
+		return ""
+	case []string:
+		return strings.Join(v, ", ")
+	case string:
+		return v
+	}
+
+	return notion.PropertyPlainText(property)
+}
+
+// value value of property for JSON, lists are []string, numbers are json.Number and empty values are nil
+func (codec *csvCodec) value(property notion.Property) interface{} {
+	switch p := property.Interface().(type) {
+	case *notion.PropertyTitle:
+		return codec.text(p.RichText())
+	case *notion.PropertyRichText:
+		return codec.text(p.RichText())
+	case *notion.PropertyNumber:
+		return number(p.Decimal())
+	case *notion.PropertyCheckbox:
+		return p.Checked()
+	case *notion.PropertyMultiSelect:
+		names := []string{}
+		for _, option := range p.Options() {
+			names = append(names, option.Name)
+		}
+		return names
+	case *notion.PropertyDate:
+		return isoDate(p.Date())
+	case *notion.PropertyPeople:
+		names := []string{}
+		users := p.Users()
+		for i := range users {
+			names = append(names, codec.user(&users[i]))
+		}
+		return names
+	case *notion.PropertyCreatedBy:
+		if user := p.User(); user != nil {
+			return codec.user(user)
+		}
+		return nil
+	case *notion.PropertyLastEditedBy:
+		if user := p.User(); user != nil {
+			return codec.user(user)
+		}
+		return nil
+	case *notion.PropertyRelation:
+		return p.PageIDs()
+	case *notion.PropertyFiles:
+		names := []string{}
+		for _, file := range p.Files() {
+			names = append(names, file.Name)
+		}
+		return names
+	case *notion.PropertyFormula:
+		t, v := p.Formula()
+		switch t {
+		case "number":
+			return number(p.Decimal())
+		case "boolean":
+			return v
+		case "date":
+			date, _ := v.(*notion.Date)
+			return isoDate(date)
+		}
+	case *notion.PropertyRollup:
+		switch p.RollupType() {
+		case "number":
+			return number(p.Decimal())
+		case "date":
+			return isoDate(p.Date())
+		case "array":
+			values := []string{}
+			for _, v := range p.Array() {
+				if text := codec.cell(v); len(text) > 0 {
+					values = append(values, text)
+				}
+			}
+			return values
+		}
+	}
+
+	if text := notion.PropertyPlainText(property); len(text) > 0 {
+		return text
+	}
+
+	return nil
+}
+
+func (codec *csvCodec) text(text []notion.RichText) interface{} {
+	if len(text) == 0 {
+		return nil
+	}
+	if codec.markdown {
+		return notion.MarkdownRichText(text, nil)
+	}
+
+	return notion.PlainText(text)
+}
+
+func (codec *csvCodec) user(user *notion.User) string {
+	if email := user.Email(); len(email) > 0 && !codec.names {
+		return email
+	}
+
+	return user.Name()
+}
+
+func number(decimal json.Number) interface{} {
+	if len(decimal) == 0 {
+		return nil
+	}
+
+	return decimal
+}
+
+func isoDate(date *notion.Date) interface{} {
+	if date == nil || len(date.Start) == 0 {
+		return nil
+	}
+	if len(date.End) > 0 {
+		return date.Start + "/" + date.End
+	}
+
+	return date.Start
+}
+
+// row cells of page by name of property
+func (codec *csvCodec) row(page *notion.Page) map[string]string {
+	values := map[string]string{}
+
+	for _, property := range page.Properties() {
+		values[property.Name()] = codec.cell(property)
+	}
+
+	return values
+}
+
+// property make property from cell, empty cell clears value
+func (codec *csvCodec) property(name, cell string) (notion.Property, error) {
+	configuration, ok := codec.schema[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown property '%s'", name)
+	}
+	t := configuration.Type()
+
+	if len(strings.TrimSpace(cell)) == 0 {
+		var v interface{}
+		switch t {
+		case notion.TypePropertyTitle, notion.TypePropertyRichText, notion.TypePropertyMultiSelect,
+			notion.TypePropertyPeople, notion.TypePropertyRelation:
+			v = []interface{}{}
+		case notion.TypePropertyCheckbox:
+			v = false
+		}
+		return notion.AssignProperty(name, notion.JSON{"type": t, t: v})
+	}
+
+	switch t {
+	case notion.TypePropertyTitle:
+		return notion.NewPropertyTitle(name, []notion.RichText{*notion.NewRichText(cell)}), nil
+	case notion.TypePropertyRichText:
+		return notion.NewPropertyRichText(name, []notion.RichText{*notion.NewRichText(cell)}), nil
+	case notion.TypePropertyPeople:
+		ids, err := codec.userIDs(cell)
+		if err != nil {
+			return nil, fmt.Errorf("property '%s': %w", name, err)
+		}
+		cell = strings.Join(ids, ",")
+	}
+
+	return parseProperty(name, t, cell)
+}
+
+// userIDs IDs of users of comma separated emails, names or IDs
+func (codec *csvCodec) userIDs(value string) ([]string, error) {
+	if codec.users == nil {
+		results, err := collect(0, codec.c.nt.ListAllUsers)
+		if err != nil {
+			return nil, fmt.Errorf("users: %w", err)
+		}
+		codec.users = []notion.User{}
+		for _, j := range results {
+			codec.users = append(codec.users, notion.User{ID: j.GetString("id"), JSON: j})
+		}
+	}
+
+	ids := []string{}
+	for _, name := range splitList(value) {
+		found := ""
+		for _, user := range codec.users {
+			if user.ID == name || strings.EqualFold(user.Email(), name) || user.Name() == name {
+				found = user.ID
+				break
+			}
+		}
+		if len(found) == 0 {
+			return nil, fmt.Errorf("user '%s' is not found", name)
+		}
+		ids = append(ids, found)
+	}
+
+	return ids, nil
+}
+
+// readCSV read header and records of CSV file
+func readCSV(name string) ([]string, [][]string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", name, err)
+	}
+	if len(records) == 0 {
+		return nil, nil, fmt.Errorf("%s: no header", name)
+	}
+
+	header := records[0]
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	return header, records[1:], nil
+}
+
+// writeCSV write CSV file through temporary file
+func writeCSV(name string, header []string, records [][]string) error {
+	tmp := name + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(f)
+	w.Write(header)
+	w.WriteAll(records)
+	if err := w.Error(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, name)
+}
@@ -0,0 +1,421 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hunydev/notion"
+)
+
+// rawFilter filter of database query written as JSON of Notion API
+type rawFilter notion.JSON
+
+func (filter rawFilter) Json() notion.JSON {
+	return notion.JSON(filter)
+}
+
+func dbList(c *cli, args []string) error {
+	fs := c.flags("db list")
+	limit := fs.Int("limit", 0, "maximum number of databases, 0 is all")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return errUsage
+	}
+
+	results, err := collect(*limit, c.nt.ListDatabases)
+	if err != nil {
+		return err
+	}
+
+	t := newTable("ID", "TITLE", "LAST EDITED")
+	for _, j := range results {
+		t.add(j.GetString("id"), objectTitle(j), j.GetString("last_edited_time"))
+	}
+
+	return c.print(results, t)
+}
+
+func dbGet(c *cli, args []string) error {
+	fs := c.flags("db get")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errUsage
+	}
+
+	database, err := c.nt.RetrieveDatabase(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	t := newTable("NAME", "TYPE", "ID", "DETAIL")
+	for _, configuration := range sortedConfigurations(database) {
+		t.add(configuration.Name(), configuration.Type(), configuration.ID(), configurationDetail(configuration))
+	}
+
+	return c.print(database.JSON, t)
+}
+
+func dbQuery(c *cli, args []string) error {
+	fs := c.flags("db query")
+	filter := fs.String("filter", "", "filter as JSON of Notion API, e.g. '{\"property\":\"Done\",\"checkbox\":{\"equals\":true}}'")
+	sorts := &stringList{}
+	fs.Var(sorts, "sort", "sort by property or created_time/last_edited_time, as name[:asc|desc] (repeatable)")
+	columns := fs.String("columns", "", "comma separated properties shown in table, default is all")
+	limit := fs.Int("limit", 0, "maximum number of pages, 0 is all")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errUsage
+	}
+
+	f, list, err := parseQuery(*filter, *sorts)
+	if err != nil {
+		return err
+	}
+
+	DatabaseID := fs.Arg(0)
+	results, err := collect(*limit, func(pagination *notion.PaginationRequest) (*notion.PaginationResponse, error) {
+		return c.nt.QueryDatabase(DatabaseID, pagination, f, list)
+	})
+	if err != nil {
+		return err
+	}
+
+	pages := make([]*notion.Page, 0, len(results))
+	for _, j := range results {
+		pages = append(pages, &notion.Page{JSON: j})
+	}
+
+	names := []string{}
+	if len(*columns) > 0 {
+		for _, name := range strings.Split(*columns, ",") {
+			names = append(names, strings.TrimSpace(name))
+		}
+	} else if len(pages) > 0 {
+		names = propertyNames(pages[0])
+	}
+
+	t := newTable(append([]string{"ID"}, names...)...)
+	for _, page := range pages {
+		values := map[string]string{}
+		for _, property := range page.Properties() {
+			values[property.Name()] = notion.PropertyPlainText(property)
+		}
+
+		row := []string{page.ID()}
+		for _, name := range names {
+			row = append(row, values[name])
+		}
+		t.add(row...)
+	}
+
+	return c.print(results, t)
+}
+
+// dbExport write pages of query as CSV or NDJSON, values of properties are flattened by csvCodec
+func dbExport(c *cli, args []string) error {
+	fs := c.flags("db export")
+	filter := fs.String("filter", "", "filter as JSON of Notion API, same as db query")
+	sorts := &stringList{}
+	fs.Var(sorts, "sort", "sort by property or created_time/last_edited_time, as name[:asc|desc] (repeatable)")
+	limit := fs.Int("limit", 0, "maximum number of pages, 0 is all")
+	to := fs.String("to", "", "output file, default is stdout")
+	format := fs.String("format", "", "csv or ndjson, default is by extension of --to or csv")
+	columns := fs.String("columns", "", "comma separated properties in order as property[=header], default is all")
+	id := fs.Bool("id", false, "add ID of page as first column")
+	people := fs.String("people", "email", "people as email or name")
+	richText := fs.String("rich-text", "plain", "title and rich text as plain or markdown")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errUsage
+	}
+
+	if len(*format) == 0 {
+		switch strings.ToLower(filepath.Ext(*to)) {
+		case ".ndjson", ".jsonl":
+			*format = "ndjson"
+		default:
+			*format = "csv"
+		}
+	}
+	if *format != "csv" && *format != "ndjson" {
+		return fmt.Errorf("format must be csv or ndjson")
+	}
+	if *people != "email" && *people != "name" {
+		return fmt.Errorf("people must be email or name")
+	}
+	if *richText != "plain" && *richText != "markdown" {
+		return fmt.Errorf("rich-text must be plain or markdown")
+	}
+
+	f, list, err := parseQuery(*filter, *sorts)
+	if err != nil {
+		return err
+	}
+
+	DatabaseID := fs.Arg(0)
+	database, err := c.nt.RetrieveDatabase(DatabaseID)
+	if err != nil {
+		return err
+	}
+	codec := newCSVCodec(c, database)
+	codec.names = *people == "name"
+	codec.markdown = *richText == "markdown"
+
+	names, header, err := exportColumns(codec, *columns)
+	if err != nil {
+		return err
+	}
+	if *id {
+		header = append([]string{"id"}, header...)
+	}
+
+	results, err := collect(*limit, func(pagination *notion.PaginationRequest) (*notion.PaginationResponse, error) {
+		return c.nt.QueryDatabase(DatabaseID, pagination, f, list)
+	})
+	if err != nil {
+		return err
+	}
+
+	b := &bytes.Buffer{}
+	w := csv.NewWriter(b)
+	if *format == "csv" {
+		w.Write(header)
+	}
+	for _, j := range results {
+		page := &notion.Page{JSON: j}
+		properties := map[string]notion.Property{}
+		for _, property := range page.Properties() {
+			properties[property.Name()] = property
+		}
+
+		if *format == "csv" {
+			record := []string{}
+			if *id {
+				record = append(record, page.ID())
+			}
+			for _, name := range names {
+				cell := ""
+				if property, ok := properties[name]; ok {
+					cell = codec.cell(property)
+				}
+				record = append(record, cell)
+			}
+			w.Write(record)
+			continue
+		}
+
+		values := []interface{}{}
+		if *id {
+			values = append(values, page.ID())
+		}
+		for _, name := range names {
+			var v interface{}
+			if property, ok := properties[name]; ok {
+				v = codec.value(property)
+			}
+			values = append(values, v)
+		}
+
+		// keys of object are in order of columns
+		line := &bytes.Buffer{}
+		line.WriteString("{")
+		for i, key := range header {
+			k, _ := json.Marshal(key)
+			value, err := json.Marshal(values[i])
+			if err != nil {
+				return err
+			}
+			if i > 0 {
+				line.WriteString(",")
+			}
+			line.Write(k)
+			line.WriteString(":")
+			line.Write(value)
+		}
+		line.WriteString("}\n")
+		b.Write(line.Bytes())
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+
+	if len(*to) == 0 {
+		_, err := c.stdout.Write(b.Bytes())
+		return err
+	}
+
+	tmp := *to + ".tmp"
+	if err := os.WriteFile(tmp, b.Bytes(), 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, *to); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.stderr, "%d pages written to %s\n", len(results), *to)
+
+	return nil
+}
+
+// exportColumns names of properties and header of columns of property[=header] list, all properties when empty
+func exportColumns(codec *csvCodec, columns string) ([]string, []string, error) {
+	names := []string{}
+	header := []string{}
+
+	if len(strings.TrimSpace(columns)) == 0 {
+		if len(codec.titleName) > 0 {
+			names = append(names, codec.titleName)
+		}
+		others := []string{}
+		for name := range codec.schema {
+			if name != codec.titleName {
+				others = append(others, name)
+			}
+		}
+		sort.Strings(others)
+		names = append(names, others...)
+		return names, append(header, names...), nil
+	}
+
+	for _, column := range strings.Split(columns, ",") {
+		name, title := strings.TrimSpace(column), ""
+		if i := strings.Index(name, "="); i >= 0 {
+			name, title = strings.TrimSpace(name[:i]), strings.TrimSpace(name[i+1:])
+		}
+		if _, ok := codec.schema[name]; !ok {
+			return nil, nil, fmt.Errorf("unknown property '%s'", name)
+		}
+		if len(title) == 0 {
+			title = name
+		}
+		names = append(names, name)
+		header = append(header, title)
+	}
+
+	return names, header, nil
+}
+
+// parseQuery parse filter and sorts of flags of query
+func parseQuery(filter string, sorts []string) (notion.Filter, []notion.Sort, error) {
+	var f notion.Filter
+	if len(filter) > 0 {
+		//numbers are kept as written, e.g. decimals of number filter
+		d := json.NewDecoder(strings.NewReader(filter))
+		d.UseNumber()
+		j := notion.JSON{}
+		if err := d.Decode(&j); err != nil {
+			return nil, nil, fmt.Errorf("invalid filter: %w", err)
+		}
+		if d.More() {
+			return nil, nil, fmt.Errorf("invalid filter: data after JSON object")
+		}
+		f = rawFilter(j)
+	}
+
+	list := []notion.Sort{}
+	for _, s := range sorts {
+		sort, err := parseSort(s)
+		if err != nil {
+			return nil, nil, err
+		}
+		list = append(list, sort)
+	}
+
+	return f, list, nil
+}
+
+// parseSort parse name[:asc|desc], created_time and last_edited_time are timestamps
+func parseSort(s string) (notion.Sort, error) {
+	sort := notion.Sort{Direction: notion.Ascending}
+
+	name := s
+	if i := strings.LastIndex(s, ":"); i >= 0 {
+		switch strings.ToLower(s[i+1:]) {
+		case "asc", "ascending":
+			name = s[:i]
+		case "desc", "descending":
+			name = s[:i]
+			sort.Direction = notion.Descending
+		}
+	}
+
+	switch name {
+	case "":
+		return sort, fmt.Errorf("invalid sort '%s'", s)
+	case string(notion.CreatedTime), string(notion.LastEditedTime):
+		sort.Timestamp = notion.Timestamp(name)
+	default:
+		sort.Property = name
+	}
+
+	return sort, nil
+}
+
+// propertyNames names of properties of page, title is first and others are sorted
+func propertyNames(page *notion.Page) []string {
+	title := []string{}
+	names := []string{}
+
+	for _, property := range page.Properties() {
+		if property.Type() == notion.TypePropertyTitle {
+			title = append(title, property.Name())
+			continue
+		}
+		names = append(names, property.Name())
+	}
+	sort.Strings(names)
+
+	return append(title, names...)
+}
+
+func sortedConfigurations(database *notion.Database) []notion.Configuration {
+	configurations := database.Properties()
+	sort.Slice(configurations, func(i, j int) bool {
+		return configurations[i].Name() < configurations[j].Name()
+	})
+
+	return configurations
+}
+
+// configurationDetail short description of configuration, e.g. options of select
+func configurationDetail(configuration notion.Configuration) string {
+	switch c := configuration.(type) {
+	case *notion.ConfigurationNumber:
+		return c.Format()
+	case *notion.ConfigurationSelect:
+		return optionNames(c.Options())
+	case *notion.ConfigurationMultiSelect:
+		return optionNames(c.Options())
+	case *notion.ConfigurationFormula:
+		return c.Expression()
+	case *notion.ConfigurationRelation:
+		return c.DatabaseID()
+	case *notion.ConfigurationRollup:
+		return c.RelationPropertyName() + "." + c.RollupPropertyName() + " (" + c.Function() + ")"
+	}
+
+	return ""
+}
+
+func optionNames(options []notion.SelectOption) string {
+	names := []string{}
+	for _, option := range options {
+		names = append(names, option.Name)
+	}
+
+	return strings.Join(names, ", ")
+}
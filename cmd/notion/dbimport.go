@@ -0,0 +1,463 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hunydev/notion"
+)
+
+// dateLayouts layouts of dates in CSV besides ISO 8601, month first
+var dateLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006/01/02",
+	"2006/01/02 15:04",
+	"2006.01.02",
+	"1/2/2006",
+	"1/2/2006 15:04",
+	"1/2/2006 3:04 PM",
+	"1/2/06",
+	"Jan 2, 2006",
+	"January 2, 2006",
+	"Jan 2 2006",
+	"2 Jan 2006",
+	"2 January 2006",
+	"02-Jan-2006",
+}
+
+// dateLayoutsDayFirst layouts of dates which are read instead of month first ones by --day-first
+var dateLayoutsDayFirst = map[string]string{
+	"1/2/2006":         "2/1/2006",
+	"1/2/2006 15:04":   "2/1/2006 15:04",
+	"1/2/2006 3:04 PM": "2/1/2006 3:04 PM",
+	"1/2/06":           "2/1/06",
+}
+
+// csvImporter coerce cells of CSV to properties of database
+type csvImporter struct {
+	codec         *csvCodec
+	createOptions bool
+	layouts       []string
+	location      *time.Location
+	//options names of options of select and multi-select properties by lower case name
+	options map[string]map[string]string
+}
+
+type importRow struct {
+	Row    int    `json:"row"`
+	Key    string `json:"key"`
+	Status string `json:"status"`
+	PageID string `json:"page_id,omitempty"`
+	Error  string `json:"error,omitempty"`
+
+	properties []notion.Property
+}
+
+func dbImport(c *cli, args []string) error {
+	fs := c.flags("db import")
+	maps := &stringList{}
+	fs.Var(maps, "map", "column of CSV for property as column=property (repeatable), default is column of same name")
+	createOptions := fs.Bool("create-options", false, "add unknown options of select and multi-select, otherwise row is failed")
+	dayFirst := fs.Bool("day-first", false, "read dates like 02/01/2006 as day/month/year")
+	timeZone := fs.String("time-zone", "UTC", "time zone of dates with time without offset")
+	concurrency := fs.Int("concurrency", 3, "number of pages created at once")
+	rate := fs.Float64("rate", 3, "maximum requests per second")
+	report := fs.String("report", "", "write result of each row to CSV file")
+	dryRun := fs.Bool("dry-run", false, "check rows without creating pages")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return errUsage
+	}
+	if *concurrency < 1 || *rate <= 0 {
+		return fmt.Errorf("concurrency and rate must be positive")
+	}
+
+	location, err := time.LoadLocation(*timeZone)
+	if err != nil {
+		return err
+	}
+
+	DatabaseID, file := fs.Arg(0), fs.Arg(1)
+	database, err := c.nt.RetrieveDatabase(DatabaseID)
+	if err != nil {
+		return err
+	}
+
+	header, records, err := readCSV(file)
+	if err != nil {
+		return err
+	}
+
+	importer := newCSVImporter(c, database, *createOptions, *dayFirst, location)
+	columns, err := importer.columns(header, *maps, func(warning string) {
+		fmt.Fprintf(c.stderr, "notion db import: %s\n", warning)
+	})
+	if err != nil {
+		return err
+	}
+
+	// cells are coerced in order, so options are created by first spelling
+	rows := make([]*importRow, len(records))
+	for i, record := range records {
+		rows[i] = importer.row(i+2, record, columns)
+	}
+
+	if !*dryRun {
+		createPages(c, notion.NewParentDatabase(database.ID()), rows, *concurrency, *rate)
+	}
+
+	t := newTable("ROW", "KEY", "STATUS", "PAGE ID", "ERROR")
+	failed := 0
+	reportRecords := [][]string{}
+	for _, row := range rows {
+		if row.Status == "failed" {
+			failed++
+		}
+		t.add(strconv.Itoa(row.Row), row.Key, row.Status, row.PageID, row.Error)
+		reportRecords = append(reportRecords, []string{strconv.Itoa(row.Row), row.Key, row.Status, row.PageID, row.Error})
+	}
+
+	if len(*report) > 0 {
+		if err := writeCSV(*report, []string{"row", "key", "status", "page_id", "error"}, reportRecords); err != nil {
+			return err
+		}
+	}
+	if err := c.print(rows, t); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d rows failed", failed, len(rows))
+	}
+
+	return nil
+}
+
+func newCSVImporter(c *cli, database *notion.Database, createOptions, dayFirst bool, location *time.Location) *csvImporter {
+	importer := &csvImporter{
+		codec:         newCSVCodec(c, database),
+		createOptions: createOptions,
+		location:      location,
+		options:       map[string]map[string]string{},
+	}
+
+	for _, layout := range dateLayouts {
+		if dayFirst && len(dateLayoutsDayFirst[layout]) > 0 {
+			layout = dateLayoutsDayFirst[layout]
+		}
+		importer.layouts = append(importer.layouts, layout)
+	}
+
+	for name, configuration := range importer.codec.schema {
+		var options []notion.SelectOption
+		switch c := configuration.(type) {
+		case *notion.ConfigurationSelect:
+			options = c.Options()
+		case *notion.ConfigurationMultiSelect:
+			options = c.Options()
+		default:
+			continue
+		}
+		importer.options[name] = map[string]string{}
+		for _, option := range options {
+			importer.options[name][strings.ToLower(option.Name)] = option.Name
+		}
+	}
+
+	return importer
+}
+
+// columns properties of columns of header, columns which are not writable properties are skipped with warning
+func (importer *csvImporter) columns(header []string, maps []string, warn func(warning string)) (map[int]string, error) {
+	assignments, err := parseAssignments(maps)
+	if err != nil {
+		return nil, err
+	}
+	mapping := map[string]string{}
+	for _, assignment := range assignments {
+		mapping[assignment[0]] = assignment[1]
+	}
+
+	columns := map[int]string{}
+	for i, column := range header {
+		property, mapped := mapping[column]
+		if !mapped {
+			property = column
+		}
+		if _, ok := importer.codec.schema[property]; !ok {
+			if mapped {
+				return nil, fmt.Errorf("unknown property '%s' of column '%s'", property, column)
+			}
+			warn(fmt.Sprintf("column '%s' is not property of database, skipped", column))
+			continue
+		}
+		if !importer.codec.writable(property) {
+			warn(fmt.Sprintf("property '%s' can not be written, column '%s' is skipped", property, column))
+			continue
+		}
+		columns[i] = property
+	}
+
+	title := importer.codec.titleName
+	for _, property := range columns {
+		if property == title {
+			return columns, nil
+		}
+	}
+
+	return nil, fmt.Errorf("CSV has no column of title property '%s'", title)
+}
+
+// row coerce cells of record, errors of all cells are reported together
+func (importer *csvImporter) row(number int, record []string, columns map[int]string) *importRow {
+	row := &importRow{Row: number, Status: "valid"}
+
+	indexes := []int{}
+	for i := range columns {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	errs := []string{}
+	for _, i := range indexes {
+		name := columns[i]
+		cell := ""
+		if i < len(record) {
+			cell = strings.TrimSpace(record[i])
+		}
+		if name == importer.codec.titleName {
+			row.Key = cell
+		}
+		if len(cell) == 0 {
+			continue
+		}
+
+		property, err := importer.property(name, cell)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		row.properties = append(row.properties, property)
+	}
+
+	if len(errs) > 0 {
+		row.Status = "failed"
+		row.Error = strings.Join(errs, "; ")
+	}
+
+	return row
+}
+
+// property coerce cell to type of property
+func (importer *csvImporter) property(name, cell string) (notion.Property, error) {
+	switch configuration := importer.codec.schema[name].(type) {
+	case *notion.ConfigurationNumber:
+		number, err := notion.ParseNumber(cell, configuration.Format())
+		if err != nil {
+			return nil, err
+		}
+		return notion.NewPropertyNumberDecimal(name, number)
+	case *notion.ConfigurationCheckbox:
+		checked, err := parseCheckbox(cell)
+		if err != nil {
+			return nil, err
+		}
+		return notion.NewPropertyCheckbox(name, checked), nil
+	case *notion.ConfigurationDate:
+		date, err := importer.date(cell)
+		if err != nil {
+			return nil, err
+		}
+		return notion.NewPropertyDate(name, date), nil
+	case *notion.ConfigurationSelect:
+		option, err := importer.option(name, cell)
+		if err != nil {
+			return nil, err
+		}
+		return notion.NewPropertySelect(name, &notion.SelectOption{Name: option}), nil
+	case *notion.ConfigurationMultiSelect:
+		options := []notion.SelectOption{}
+		for _, item := range splitList(cell) {
+			option, err := importer.option(name, item)
+			if err != nil {
+				return nil, err
+			}
+			options = append(options, notion.SelectOption{Name: option})
+		}
+		return notion.NewPropertyMultiSelect(name, options...), nil
+	}
+
+	return importer.codec.property(name, cell)
+}
+
+// option name of option matched case-insensitively, unknown option is added when createOptions is set
+func (importer *csvImporter) option(name, value string) (string, error) {
+	options := importer.options[name]
+	if option, ok := options[strings.ToLower(value)]; ok {
+		return option, nil
+	}
+	if !importer.createOptions {
+		return "", fmt.Errorf("unknown option '%s', use --create-options to add it", value)
+	}
+	if strings.Contains(value, ",") {
+		return "", fmt.Errorf("option '%s' contains comma", value)
+	}
+
+	options[strings.ToLower(value)] = value
+
+	return value, nil
+}
+
+// date parse date or range of dates separated by "/", " → " or " to "
+func (importer *csvImporter) date(cell string) (*notion.Date, error) {
+	start, hasTime, err := importer.parseDate(cell)
+	if err == nil {
+		return importer.newDate(start, nil, hasTime), nil
+	}
+
+	for _, separator := range []string{" → ", " to ", " - ", "/"} {
+		parts := strings.SplitN(cell, separator, 2)
+		if len(parts) != 2 {
+			continue
+		}
+		start, startTime, err := importer.parseDate(strings.TrimSpace(parts[0]))
+		if err != nil {
+			continue
+		}
+		end, endTime, err := importer.parseDate(strings.TrimSpace(parts[1]))
+		if err != nil {
+			continue
+		}
+		return importer.newDate(start, &end, startTime || endTime), nil
+	}
+
+	return nil, fmt.Errorf("invalid date '%s'", cell)
+}
+
+func (importer *csvImporter) parseDate(value string) (time.Time, bool, error) {
+	if t, hasTime, err := notion.ParseDate(value, importer.location); err == nil {
+		return t, hasTime, nil
+	}
+
+	for _, layout := range importer.layouts {
+		if t, err := time.ParseInLocation(layout, value, importer.location); err == nil {
+			return t, strings.Contains(layout, "04"), nil
+		}
+	}
+
+	return time.Time{}, false, fmt.Errorf("invalid date '%s'", value)
+}
+
+func (importer *csvImporter) newDate(start time.Time, end *time.Time, hasTime bool) *notion.Date {
+	switch {
+	case end == nil && hasTime:
+		return notion.NewDate(start)
+	case end == nil:
+		return notion.NewDateOnly(start)
+	case hasTime:
+		return notion.NewDateRange(start, *end)
+	}
+
+	return notion.NewDateOnlyRange(start, *end)
+}
+
+// parseCheckbox read yes/no, y/n, true/false, 1/0, x, checked and unchecked
+func parseCheckbox(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "yes", "y", "true", "t", "1", "x", "✓", "✔", "checked", "on":
+		return true, nil
+	case "no", "n", "false", "f", "0", "", "unchecked", "off":
+		return false, nil
+	}
+
+	return false, fmt.Errorf("invalid checkbox '%s'", value)
+}
+
+// createPages create pages of valid rows by workers, requests are spaced to stay within rate per second.
+// request which is rate limited or failed by server is retried
+func createPages(c *cli, parent *notion.Parent, rows []*importRow, concurrency int, rate float64) {
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / rate))
+	defer ticker.Stop()
+
+	queue := make(chan *importRow)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for row := range queue {
+				page, err := createPage(c, parent, row.properties, ticker.C)
+				if err != nil {
+					row.Status = "failed"
+					row.Error = err.Error()
+					continue
+				}
+				row.Status = "created"
+				row.PageID = page.ID()
+			}
+		}()
+	}
+
+	for _, row := range rows {
+		if row.Status == "valid" {
+			queue <- row
+		}
+	}
+	close(queue)
+	wg.Wait()
+}
+
+func createPage(c *cli, parent *notion.Parent, properties []notion.Property, tick <-chan time.Time) (*notion.Page, error) {
+	wait := time.Second
+	for attempt := 0; ; attempt++ {
+		<-tick
+		page, err := c.nt.CreatePage(parent, properties)
+		if err == nil {
+			return page, nil
+		}
+
+		retry, after := createRetry(err)
+		if attempt >= 3 || !retry {
+			return nil, err
+		}
+		delay := wait
+		if after > delay {
+			delay = after
+		}
+		time.Sleep(delay)
+		wait *= 2
+	}
+}
+
+// createRetry check page was not created by failed request, so it can be retried without duplicate row.
+// rate limited request and connection which failed before request was sent are retried, but 5xx and timeout are not
+func createRetry(err error) (bool, time.Duration) {
+	var status interface{ StatusCode() int }
+	if errors.As(err, &status) {
+		if status.StatusCode() != http.StatusTooManyRequests {
+			return false, 0
+		}
+		var header interface{ RetryAfter() string }
+		if errors.As(err, &header) {
+			return true, retryAfter(header.RetryAfter())
+		}
+		return true, 0
+	}
+
+	var dnsError *net.DNSError
+	var opError *net.OpError
+	if errors.As(err, &dnsError) || (errors.As(err, &opError) && opError.Op == "dial") {
+		return true, 0
+	}
+
+	return false, 0
+}
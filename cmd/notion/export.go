@@ -0,0 +1,602 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hunydev/notion"
+)
+
+// exportState progress of export, written after each file so interrupted export can be resumed
+type exportState struct {
+	Root    string                  `json:"root"`
+	Entries map[string]*exportEntry `json:"entries"`
+}
+
+type exportEntry struct {
+	Object string `json:"object"`
+	//Path file of page or database, relative to directory of export
+	Path           string        `json:"path"`
+	LastEditedTime string        `json:"last_edited_time"`
+	Children       []exportChild `json:"children"`
+	Done           bool          `json:"done"`
+}
+
+type exportChild struct {
+	ID     string `json:"id"`
+	Object string `json:"object"`
+	Title  string `json:"title"`
+}
+
+type exporter struct {
+	c         *cli
+	client    *http.Client
+	dir       string
+	statePath string
+	images    string
+
+	state *exportState
+	//used path -> ID, paths are unique
+	used    map[string]string
+	visited map[string]bool
+	//databases ID of parent page -> databases
+	databases map[string][]exportChild
+	//pages ID of parent page or database -> pages found by search, paths are assigned before files are written
+	pages map[string][]exportChild
+	//assigned IDs whose descendants have paths
+	assigned map[string]bool
+}
+
+// maxImageSize limit of downloaded image
+const maxImageSize = 50 << 20
+
+func export(c *cli, args []string) error {
+	fs := c.flags("export")
+	to := fs.String("to", "", "directory of export")
+	images := fs.String("images", "link", "images: link (URL of Notion) or download")
+	statePath := fs.String("state", "", "file of export state, default is .notion-export.json in directory")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || len(*to) == 0 {
+		return errUsage
+	}
+	if *images != "link" && *images != "download" {
+		return fmt.Errorf("images must be link or download")
+	}
+
+	e := &exporter{
+		c:         c,
+		client:    &http.Client{Timeout: c.timeout},
+		dir:       *to,
+		statePath: *statePath,
+		images:    *images,
+		used:      map[string]string{},
+		visited:   map[string]bool{},
+		databases: map[string][]exportChild{},
+		pages:     map[string][]exportChild{},
+		assigned:  map[string]bool{},
+	}
+	if len(e.statePath) == 0 {
+		e.statePath = filepath.Join(e.dir, ".notion-export.json")
+	}
+	if err := os.MkdirAll(e.dir, 0755); err != nil {
+		return err
+	}
+	if err := e.loadState(fs.Arg(0)); err != nil {
+		return err
+	}
+	if err := e.findObjects(); err != nil {
+		return err
+	}
+
+	root := exportChild{ID: fs.Arg(0), Object: string(notion.ObjectPage)}
+	if page, err := c.nt.RetrievePage(root.ID); err == nil {
+		root.ID = page.ID()
+		root.Title = notion.PlainText(page.Title())
+	} else {
+		database, dbErr := c.nt.RetrieveDatabase(root.ID)
+		if dbErr != nil {
+			return err
+		}
+		root.ID = database.ID()
+		root.Object = string(notion.ObjectDatabase)
+		root.Title = notion.PlainText(database.Title())
+	}
+	e.assign(root, "")
+	e.assignTree(root)
+
+	if err := e.visit(root); err != nil {
+		return err
+	}
+
+	t := newTable("OBJECT", "ID", "PATH")
+	ids := make([]string, 0, len(e.state.Entries))
+	for id := range e.state.Entries {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return e.state.Entries[ids[i]].Path < e.state.Entries[ids[j]].Path
+	})
+	for _, id := range ids {
+		entry := e.state.Entries[id]
+		t.add(entry.Object, id, entry.Path)
+	}
+
+	return c.print(e.state, t)
+}
+
+func (e *exporter) loadState(root string) error {
+	e.state = &exportState{Root: root, Entries: map[string]*exportEntry{}}
+
+	b, err := os.ReadFile(e.statePath)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	state := &exportState{}
+	if err := json.Unmarshal(b, state); err != nil {
+		return fmt.Errorf("invalid state %s: %w", e.statePath, err)
+	}
+	if state.Root != root {
+		return fmt.Errorf("state %s is export of %s, use other directory or --state", e.statePath, state.Root)
+	}
+	if state.Entries != nil {
+		e.state = state
+	}
+	for id, entry := range e.state.Entries {
+		e.used[entry.Path] = id
+	}
+
+	return nil
+}
+
+func (e *exporter) saveState() error {
+	b, err := json.MarshalIndent(e.state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := e.statePath + ".tmp"
+	if err := os.WriteFile(tmp, b, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, e.statePath)
+}
+
+// findObjects index databases and pages by parent, parent of database is not provided by every API version
+func (e *exporter) findObjects() error {
+	results, err := collect(0, func(pagination *notion.PaginationRequest) (*notion.PaginationResponse, error) {
+		return e.c.nt.Search("", pagination, notion.ObjectDatabase, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, j := range results {
+		database := &notion.Database{JSON: j}
+		parent := database.Parent()
+		if parent == nil || len(parent.ID) == 0 {
+			continue
+		}
+
+		e.databases[parent.ID] = append(e.databases[parent.ID], exportChild{
+			ID:     database.ID(),
+			Object: string(notion.ObjectDatabase),
+			Title:  notion.PlainText(database.Title()),
+		})
+	}
+
+	results, err = collect(0, func(pagination *notion.PaginationRequest) (*notion.PaginationResponse, error) {
+		return e.c.nt.Search("", pagination, notion.ObjectPage, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	pages := []*notion.Page{}
+	for _, j := range results {
+		pages = append(pages, &notion.Page{JSON: j})
+	}
+	sort.SliceStable(pages, func(i, j int) bool {
+		if pages[i].CreatedTime() != pages[j].CreatedTime() {
+			return pages[i].CreatedTime() < pages[j].CreatedTime()
+		}
+		return pages[i].ID() < pages[j].ID()
+	})
+	for _, page := range pages {
+		parent := page.Parent()
+		if parent == nil || len(parent.ID) == 0 {
+			continue
+		}
+
+		e.pages[parent.ID] = append(e.pages[parent.ID], exportChild{
+			ID:     page.ID(),
+			Object: string(notion.ObjectPage),
+			Title:  notion.PlainText(page.Title()),
+		})
+	}
+
+	return nil
+}
+
+// assign give path to page or database in directory, path of previous export is kept
+func (e *exporter) assign(child exportChild, dir string) *exportEntry {
+	if entry, ok := e.state.Entries[child.ID]; ok {
+		return entry
+	}
+
+	name := slug(child.Title)
+	p := path.Join(dir, name+".md")
+	if id, ok := e.used[p]; ok && id != child.ID {
+		p = path.Join(dir, name+"-"+shortID(child.ID)+".md")
+	}
+	e.used[p] = child.ID
+
+	entry := &exportEntry{Object: child.Object, Path: p}
+	e.state.Entries[child.ID] = entry
+
+	return entry
+}
+
+// assignTree assign paths of descendants found by search, so links to pages which are written later are relative
+func (e *exporter) assignTree(child exportChild) {
+	if e.assigned[child.ID] {
+		return
+	}
+	e.assigned[child.ID] = true
+
+	dir := strings.TrimSuffix(e.state.Entries[child.ID].Path, ".md")
+	children := append(append([]exportChild{}, e.pages[child.ID]...), e.databases[child.ID]...)
+	for _, grandchild := range children {
+		e.assign(grandchild, dir)
+	}
+	for _, grandchild := range children {
+		e.assignTree(grandchild)
+	}
+}
+
+// visit write page or database, then visit its children. state is saved after each file, so export can be resumed
+func (e *exporter) visit(child exportChild) error {
+	if e.visited[child.ID] {
+		return nil
+	}
+	e.visited[child.ID] = true
+
+	var children []exportChild
+	var err error
+	if child.Object == string(notion.ObjectDatabase) {
+		children, err = e.exportDatabase(child.ID)
+	} else {
+		children, err = e.exportPage(child.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, grandchild := range children {
+		if err := e.visit(grandchild); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// assignChildren give paths to children which were not found by search
+func (e *exporter) assignChildren(ID string, children []exportChild) {
+	dir := strings.TrimSuffix(e.state.Entries[ID].Path, ".md")
+	for _, child := range children {
+		e.assign(child, dir)
+	}
+}
+
+// exportPage write page and return its child pages and databases. unchanged page is not fetched
+func (e *exporter) exportPage(PageID string) ([]exportChild, error) {
+	entry := e.state.Entries[PageID]
+
+	page, err := e.c.nt.RetrievePage(PageID)
+	if err != nil {
+		return nil, fmt.Errorf("page %s: %w", PageID, err)
+	}
+	if entry.Done && entry.LastEditedTime == page.LastEditedTime() {
+		return entry.Children, nil
+	}
+
+	nodes, err := e.c.nt.FetchTree(PageID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("page %s: %w", PageID, err)
+	}
+
+	children := []exportChild{}
+	notion.WalkTree(nodes, func(node *notion.BlockNode) bool {
+		if node.Block.Type() == notion.TypeBlockChildPage {
+			title := ""
+			if j, ok := node.Block.Json().GetJSON(notion.TypeBlockChildPage); ok {
+				title = j.GetString("title")
+			}
+			children = append(children, exportChild{ID: node.Block.ID(), Object: string(notion.ObjectPage), Title: title})
+		}
+		return true
+	})
+	children = append(children, e.databases[PageID]...)
+	e.assignChildren(PageID, children)
+
+	return children, e.writePage(page, nodes, children)
+}
+
+// exportDatabase write list of rows of database, rows are always queried again since edit of row does not change database
+func (e *exporter) exportDatabase(DatabaseID string) ([]exportChild, error) {
+	database, err := e.c.nt.RetrieveDatabase(DatabaseID)
+	if err != nil {
+		return nil, fmt.Errorf("database %s: %w", DatabaseID, err)
+	}
+
+	results, err := collect(0, func(pagination *notion.PaginationRequest) (*notion.PaginationResponse, error) {
+		return e.c.nt.QueryDatabase(DatabaseID, pagination, nil, nil)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("database %s: %w", DatabaseID, err)
+	}
+
+	children := []exportChild{}
+	for _, j := range results {
+		page := &notion.Page{JSON: j}
+		children = append(children, exportChild{ID: page.ID(), Object: string(notion.ObjectPage), Title: notion.PlainText(page.Title())})
+	}
+	e.assignChildren(DatabaseID, children)
+
+	return children, e.writeDatabase(database, children)
+}
+
+func (e *exporter) writePage(page *notion.Page, nodes []*notion.BlockNode, children []exportChild) error {
+	entry := e.state.Entries[page.ID()]
+	dir := strings.TrimSuffix(entry.Path, ".md")
+
+	option := e.markdownOption(entry.Path)
+	if e.images == "download" {
+		option.ImageURL = func(BlockID, URL string) string {
+			local, err := e.download(dir+".assets", BlockID, URL)
+			if err != nil {
+				fmt.Fprintf(e.c.stderr, "notion export: image %s: %v\n", BlockID, err)
+				return URL
+			}
+			return relativeLink(entry.Path, local)
+		}
+	}
+
+	matter := frontMatter(page)
+	if err := e.writeFile(entry.Path, encodeYAML(matter), notion.MarkdownPage(page, nodes, option)); err != nil {
+		return err
+	}
+
+	entry.LastEditedTime = page.LastEditedTime()
+	entry.Children = children
+	entry.Done = true
+
+	return e.saveState()
+}
+
+// writeDatabase write list of rows
+func (e *exporter) writeDatabase(database *notion.Database, children []exportChild) error {
+	entry := e.state.Entries[database.ID()]
+
+	title := notion.PlainText(database.Title())
+	body := &strings.Builder{}
+	if len(title) > 0 {
+		body.WriteString("# " + title + "\n\n")
+	}
+	for _, child := range children {
+		name := child.Title
+		if len(name) == 0 {
+			name = "Untitled"
+		}
+		fmt.Fprintf(body, "- [%s](%s)\n", name, relativeLink(entry.Path, e.state.Entries[child.ID].Path))
+	}
+
+	matter := map[string]interface{}{
+		"title":            title,
+		"notion_id":        database.ID(),
+		"notion_object":    "database",
+		"last_edited_time": database.LastEditedTime(),
+	}
+	if err := e.writeFile(entry.Path, encodeYAML(matter), body.String()); err != nil {
+		return err
+	}
+
+	entry.LastEditedTime = database.LastEditedTime()
+	entry.Children = children
+	entry.Done = true
+
+	return e.saveState()
+}
+
+// markdownOption link pages and databases of export relatively from file
+func (e *exporter) markdownOption(from string) *notion.MarkdownOption {
+	link := func(ID string) string {
+		if entry, ok := e.state.Entries[ID]; ok {
+			return relativeLink(from, entry.Path)
+		}
+		return notion.NotionURL(ID)
+	}
+
+	return &notion.MarkdownOption{
+		PageURL:     link,
+		DatabaseURL: link,
+	}
+}
+
+func (e *exporter) writeFile(name, matter, body string) error {
+	file := filepath.Join(e.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(file, []byte("---\n"+matter+"---\n\n"+body), 0644)
+}
+
+// download save image in directory, existing file is not downloaded again
+func (e *exporter) download(dir, BlockID, URL string) (string, error) {
+	u, err := url.Parse(URL)
+	if err != nil {
+		return "", err
+	}
+
+	name := path.Join(dir, BlockID+path.Ext(u.Path))
+	file := filepath.Join(e.dir, filepath.FromSlash(name))
+	if _, err := os.Stat(file); err == nil {
+		return name, nil
+	}
+
+	resp, err := e.client.Get(URL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s", resp.Status)
+	}
+	if resp.ContentLength > maxImageSize {
+		return "", fmt.Errorf("image of %d bytes is larger than %d", resp.ContentLength, maxImageSize)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return "", err
+	}
+	f, err := os.Create(file + ".tmp")
+	if err != nil {
+		return "", err
+	}
+	n, err := io.Copy(f, io.LimitReader(resp.Body, maxImageSize+1))
+	if err == nil && n > maxImageSize {
+		err = fmt.Errorf("image is larger than %d bytes", maxImageSize)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(file + ".tmp")
+		return "", err
+	}
+
+	return name, os.Rename(file+".tmp", file)
+}
+
+// frontMatter properties of page as values of YAML
+func frontMatter(page *notion.Page) map[string]interface{} {
+	properties := map[string]interface{}{}
+	for _, property := range page.Properties() {
+		properties[property.Name()] = propertyValue(property)
+	}
+
+	return map[string]interface{}{
+		"title":            notion.PlainText(page.Title()),
+		"notion_id":        page.ID(),
+		"notion_object":    "page",
+		"last_edited_time": page.LastEditedTime(),
+		"properties":       properties,
+	}
+}
+
+// propertyValue value of property in front matter, written as --prop of page create accepts it
+func propertyValue(property notion.Property) interface{} {
+	switch p := property.Interface().(type) {
+	case *notion.PropertyNumber:
+		if n := p.Decimal(); len(n) > 0 {
+			return n
+		}
+		return nil
+	case *notion.PropertyCheckbox:
+		return p.Checked()
+	case *notion.PropertyMultiSelect:
+		list := []interface{}{}
+		for _, option := range p.Options() {
+			list = append(list, option.Name)
+		}
+		return list
+	case *notion.PropertyPeople:
+		list := []interface{}{}
+		for _, user := range p.Users() {
+			list = append(list, user.ID)
+		}
+		return list
+	case *notion.PropertyRelation:
+		list := []interface{}{}
+		for _, id := range p.PageIDs() {
+			list = append(list, id)
+		}
+		return list
+	case *notion.PropertyDate:
+		date := p.Date()
+		if date == nil || len(date.Start) == 0 {
+			return nil
+		}
+		if date.IsRange() {
+			return date.Start + "/" + date.End
+		}
+		return date.Start
+	}
+
+	if text := notion.PropertyPlainText(property); len(text) > 0 {
+		return text
+	}
+
+	return nil
+}
+
+// relativeLink link from file to other file of export, both are slash separated paths in export
+func relativeLink(from, to string) string {
+	rel, err := filepath.Rel(filepath.Dir(filepath.FromSlash(from)), filepath.FromSlash(to))
+	if err != nil {
+		return to
+	}
+
+	segments := strings.Split(filepath.ToSlash(rel), "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// slug name of file from title
+func slug(title string) string {
+	title = strings.Map(func(r rune) rune {
+		switch {
+		case r < 0x20, strings.ContainsRune(`/\:*?"<>|#%`, r):
+			return '-'
+		}
+		return r
+	}, title)
+	title = strings.Trim(title, " .-")
+
+	runes := []rune(title)
+	if len(runes) > 80 {
+		title = strings.TrimRight(string(runes[:80]), " .-")
+	}
+	if len(title) == 0 {
+		return "Untitled"
+	}
+
+	return title
+}
+
+func shortID(ID string) string {
+	ID = strings.ReplaceAll(ID, "-", "")
+	if len(ID) > 8 {
+		return ID[:8]
+	}
+
+	return ID
+}
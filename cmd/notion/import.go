@@ -0,0 +1,377 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hunydev/notion"
+)
+
+// importManifest pages created by import, re-run updates them instead of creating again
+type importManifest struct {
+	Parent  string                    `json:"parent"`
+	Entries map[string]*importedEntry `json:"entries"`
+}
+
+type importedEntry struct {
+	PageID string `json:"page_id"`
+	//Hash SHA-256 of file, empty for page of directory without file
+	Hash string `json:"hash"`
+}
+
+type importer struct {
+	c            *cli
+	dir          string
+	manifestPath string
+	manifest     *importManifest
+
+	//schema properties of parent database, nil if parent is page
+	schema    map[string]string
+	titleName string
+
+	result *table
+}
+
+func importCommand(c *cli, args []string) error {
+	fs := c.flags("import")
+	parent := fs.String("parent", "", "ID of parent page or database")
+	manifestPath := fs.String("manifest", "", "file of import manifest, default is .notion-import.json in directory")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || len(*parent) == 0 {
+		return errUsage
+	}
+
+	i := &importer{
+		c:            c,
+		dir:          fs.Arg(0),
+		manifestPath: *manifestPath,
+		titleName:    "title",
+		result:       newTable("STATUS", "PATH", "PAGE ID"),
+	}
+	if len(i.manifestPath) == 0 {
+		i.manifestPath = filepath.Join(i.dir, ".notion-import.json")
+	}
+	if err := i.loadManifest(*parent); err != nil {
+		return err
+	}
+
+	var p *notion.Parent
+	if _, err := c.nt.RetrievePage(*parent); err == nil {
+		p = notion.NewParentPage(*parent)
+	} else {
+		database, dbErr := c.nt.RetrieveDatabase(*parent)
+		if dbErr != nil {
+			return err
+		}
+		i.schema = map[string]string{}
+		for _, configuration := range database.Properties() {
+			i.schema[configuration.Name()] = configuration.Type()
+			if configuration.Type() == notion.TypePropertyTitle {
+				i.titleName = configuration.Name()
+			}
+		}
+		p = notion.NewParentDatabase(*parent)
+	}
+
+	if err := i.importDir("", p); err != nil {
+		return err
+	}
+
+	return c.print(i.manifest, i.result)
+}
+
+func (i *importer) loadManifest(parent string) error {
+	i.manifest = &importManifest{Parent: parent, Entries: map[string]*importedEntry{}}
+
+	b, err := os.ReadFile(i.manifestPath)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	manifest := &importManifest{}
+	if err := json.Unmarshal(b, manifest); err != nil {
+		return fmt.Errorf("invalid manifest %s: %w", i.manifestPath, err)
+	}
+	if manifest.Parent != parent {
+		return fmt.Errorf("manifest %s is import into %s, use --manifest for other parent", i.manifestPath, manifest.Parent)
+	}
+	if manifest.Entries != nil {
+		i.manifest = manifest
+	}
+
+	return nil
+}
+
+func (i *importer) saveManifest() error {
+	b, err := json.MarshalIndent(i.manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := i.manifestPath + ".tmp"
+	if err := os.WriteFile(tmp, b, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, i.manifestPath)
+}
+
+// importDir import Markdown files of directory under parent.
+// "name.md" and directory "name" are one page, files in directory are its children
+func (i *importer) importDir(dir string, parent *notion.Parent) error {
+	entries, err := os.ReadDir(filepath.Join(i.dir, filepath.FromSlash(dir)))
+	if err != nil {
+		return err
+	}
+
+	files := map[string]bool{}
+	dirs := map[string]bool{}
+	names := []string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		switch {
+		case entry.IsDir():
+			if strings.HasSuffix(name, ".assets") {
+				continue
+			}
+			dirs[name] = true
+		case strings.EqualFold(path.Ext(name), ".md"):
+			name = strings.TrimSuffix(name, path.Ext(name))
+			files[name] = true
+		default:
+			continue
+		}
+		if !files[name] || !dirs[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		file := ""
+		if files[name] {
+			file = path.Join(dir, name+".md")
+		}
+
+		PageID, err := i.importPage(path.Join(dir, name), file, parent)
+		if err != nil {
+			return err
+		}
+
+		if dirs[name] {
+			if err := i.importDir(path.Join(dir, name), notion.NewParentPage(PageID)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// importPage create or update page of file, page of directory without file has only title
+func (i *importer) importPage(name, file string, parent *notion.Parent) (string, error) {
+	key := name + "/"
+	source := ""
+	if len(file) > 0 {
+		key = file
+		b, err := os.ReadFile(filepath.Join(i.dir, filepath.FromSlash(file)))
+		if err != nil {
+			return "", err
+		}
+		source = string(b)
+	}
+
+	hash := ""
+	if len(file) > 0 {
+		sum := sha256.Sum256([]byte(source))
+		hash = hex.EncodeToString(sum[:])
+	}
+
+	entry, exists := i.manifest.Entries[key]
+	if exists && entry.Hash == hash {
+		i.result.add("unchanged", key, entry.PageID)
+		return entry.PageID, nil
+	}
+
+	matter, body, err := splitFrontMatter(source)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", key, err)
+	}
+
+	title := path.Base(name)
+	if t, ok := matter["title"].(string); ok && len(t) > 0 {
+		title = t
+	}
+
+	blocks := notion.ParseMarkdown(body)
+	if len(blocks) > 0 && blocks[0].Type() == notion.TypeBlockHeading1 {
+		// heading of title is written by export
+		if heading := notion.BlockPlainText(blocks[0]); heading == title || matter["title"] == nil {
+			title = heading
+			blocks = blocks[1:]
+		}
+	}
+
+	inDatabase := parent.JSON.GetString("type") == notion.TypeParentDatabase
+
+	titleName := "title"
+	if inDatabase {
+		titleName = i.titleName
+	}
+	properties := []notion.Property{notion.NewPropertyTitle(titleName, notion.ParseRichText(title, nil))}
+	if inDatabase {
+		properties = append(properties, i.frontMatterProperties(key, matter)...)
+	}
+
+	status := "created"
+	var PageID string
+	if exists {
+		status = "updated"
+		PageID = entry.PageID
+		if _, err := i.c.nt.UpdatePageProperties(PageID, properties...); err != nil {
+			return "", fmt.Errorf("%s: %w", key, err)
+		}
+		if err := i.clearContent(PageID); err != nil {
+			return "", fmt.Errorf("%s: %w", key, err)
+		}
+	} else {
+		page, err := i.c.nt.CreatePage(parent, properties)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", key, err)
+		}
+		PageID = page.ID()
+	}
+
+	if len(blocks) > 0 {
+		if _, err := i.c.nt.AppendBlockTree(PageID, blocks); err != nil {
+			return "", fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	i.manifest.Entries[key] = &importedEntry{PageID: PageID, Hash: hash}
+	if err := i.saveManifest(); err != nil {
+		return "", err
+	}
+	i.result.add(status, key, PageID)
+
+	return PageID, nil
+}
+
+// clearContent delete blocks of page, child pages are kept
+func (i *importer) clearContent(PageID string) error {
+	blocks, err := i.c.nt.RetrieveAllBlockChildren(PageID, 100)
+	if err != nil {
+		return err
+	}
+
+	for _, block := range blocks {
+		if block.Type() == notion.TypeBlockChildPage {
+			continue
+		}
+		if _, err := i.c.nt.DeleteBlock(block.ID()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// frontMatterProperties properties of database from front matter, keys in "properties" or at top level.
+// unknown names and read-only properties are skipped with warning
+func (i *importer) frontMatterProperties(key string, matter map[string]interface{}) []notion.Property {
+	values := map[string]interface{}{}
+	for name, v := range matter {
+		if _, ok := i.schema[name]; ok {
+			values[name] = v
+		}
+	}
+	if m, ok := matter["properties"].(map[string]interface{}); ok {
+		for name, v := range m {
+			values[name] = v
+		}
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	properties := []notion.Property{}
+	for _, name := range names {
+		t, ok := i.schema[name]
+		if !ok {
+			fmt.Fprintf(i.c.stderr, "notion import: %s: unknown property '%s'\n", key, name)
+			continue
+		}
+		if t == notion.TypePropertyTitle || values[name] == nil {
+			continue
+		}
+
+		property, err := parseProperty(name, t, frontMatterText(values[name]))
+		if err != nil {
+			fmt.Fprintf(i.c.stderr, "notion import: %s: %v\n", key, err)
+			continue
+		}
+		properties = append(properties, property)
+	}
+
+	return properties
+}
+
+// frontMatterText value of front matter as text of --prop, lists are comma separated
+func frontMatterText(v interface{}) string {
+	if list, ok := v.([]interface{}); ok {
+		items := []string{}
+		for _, item := range list {
+			items = append(items, fmt.Sprint(item))
+		}
+		return strings.Join(items, ",")
+	}
+
+	return fmt.Sprint(v)
+}
+
+// splitFrontMatter split YAML front matter between "---" lines from body of Markdown
+func splitFrontMatter(source string) (map[string]interface{}, string, error) {
+	matter := map[string]interface{}{}
+
+	text := strings.ReplaceAll(source, "\r\n", "\n")
+	if !strings.HasPrefix(text, "---\n") {
+		return matter, source, nil
+	}
+
+	end := strings.Index(text[4:], "\n---")
+	if end < 0 {
+		return matter, source, nil
+	}
+	yaml := text[4 : 4+end+1]
+	body := strings.TrimPrefix(text[4+end+4:], "\n")
+
+	v, err := decodeYAML(yaml)
+	if err != nil {
+		return nil, "", fmt.Errorf("front matter: %w", err)
+	}
+	if m, ok := v.(map[string]interface{}); ok {
+		matter = m
+	} else if v != nil {
+		return nil, "", fmt.Errorf("front matter is not map")
+	}
+
+	return matter, body, nil
+}
@@ -0,0 +1,229 @@
+// Command notion is command-line client of Notion API.
+//
+//	export NOTION_AUTHORIZATION=secret_xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx
+//	notion [--output json|table|yaml] <command> [flags] [arguments]
+//
+// run "notion help" for list of commands
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hunydev/notion"
+	api "github.com/hunydev/notion/api/v20220222"
+)
+
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(cli *cli, args []string) error
+}
+
+var commands []*command
+
+func init() {
+	commands = []*command{
+		{"users list", "", "list all users of workspace", usersList},
+		{"search", "[query]", "search pages and databases shared with integration", search},
+		{"db list", "", "list databases shared with integration", dbList},
+		{"db get", "<database-id>", "show properties of database", dbGet},
+		{"db query", "<database-id>", "query pages of database", dbQuery},
+		{"db export", "<database-id>", "write pages of query as CSV or NDJSON", dbExport},
+		{"db import", "<database-id> <file.csv>", "create pages of rows of CSV file", dbImport},
+		{"page get", "<page-id>", "show properties of page", pageGet},
+		{"page create", "", "create page in page or database", pageCreate},
+		{"page update", "<page-id>", "update properties of page", pageUpdate},
+		{"blocks list", "<block-id>", "list children of block or page", blocksList},
+		{"blocks append", "<block-id>", "append blocks to block or page", blocksAppend},
+		{"export", "<page-or-database-id>", "export pages as directory of Markdown files", export},
+		{"import", "<directory>", "import directory of Markdown files as pages", importCommand},
+		{"backup", "", "write pages, databases and users to zip archive", backup},
+		{"restore", "<archive>...", "restore pages and databases of backup archives in page", restore},
+		{"relay", "", "watch changes and POST signed events to webhooks of config", relayCommand},
+		{"sync", "<database-id> <file.csv>", "two-way sync of database and CSV file", syncCommand},
+	}
+}
+
+type cli struct {
+	nt      *notion.Notion
+	output  string
+	timeout time.Duration
+
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// errUsage error of arguments, usage of command is printed
+var errUsage = errors.New("invalid arguments")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	c := &cli{stdin: stdin, stdout: stdout, stderr: stderr}
+
+	global := flag.NewFlagSet("notion", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	c.output = "table"
+	global.Var((*outputFormat)(&c.output), "output", "output format: json, table or yaml")
+	global.DurationVar(&c.timeout, "timeout", 30*time.Second, "timeout of each request")
+	global.Usage = func() { c.usage(global) }
+	if err := global.Parse(args); err != nil {
+		return 2
+	}
+
+	args = global.Args()
+	if len(args) == 0 || args[0] == "help" {
+		c.usage(global)
+		return 0
+	}
+
+	cmd, rest := findCommand(args)
+	if cmd == nil {
+		fmt.Fprintf(stderr, "notion: unknown command '%s'\n", strings.Join(args, " "))
+		c.usage(global)
+		return 2
+	}
+
+	token := os.Getenv("NOTION_AUTHORIZATION")
+	if len(token) == 0 {
+		fmt.Fprintln(stderr, "notion: NOTION_AUTHORIZATION is not set")
+		return 1
+	}
+	c.nt = notion.New(api.New(token, &api.Option{
+		Timeout: c.timeout,
+	}))
+
+	if err := cmd.run(c, rest); err != nil {
+		if errors.Is(err, errUsage) {
+			fmt.Fprintf(stderr, "usage: notion %s [flags] %s\n", cmd.name, cmd.args)
+			return 2
+		}
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		fmt.Fprintf(stderr, "notion %s: %v\n", cmd.name, err)
+		return 1
+	}
+
+	return 0
+}
+
+// findCommand find command of longest name matching args, rest of args are returned
+func findCommand(args []string) (*command, []string) {
+	for n := 2; n > 0; n-- {
+		if len(args) < n {
+			continue
+		}
+		name := strings.Join(args[:n], " ")
+		for _, cmd := range commands {
+			if cmd.name == name {
+				return cmd, args[n:]
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func (c *cli) usage(global *flag.FlagSet) {
+	fmt.Fprintln(c.stderr, "usage: notion [flags] <command> [flags] [arguments]")
+	fmt.Fprintln(c.stderr, "\ncommands:")
+
+	w := tabwriter.NewWriter(c.stderr, 0, 4, 2, ' ', 0)
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %s %s\t%s\n", cmd.name, cmd.args, cmd.summary)
+	}
+	w.Flush()
+
+	fmt.Fprintln(c.stderr, "\nflags:")
+	global.PrintDefaults()
+	fmt.Fprintln(c.stderr, "\ntoken of integration is read from NOTION_AUTHORIZATION")
+}
+
+// flags new flag set of command, --output can be given after command too
+func (c *cli) flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("notion "+name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	fs.Var((*outputFormat)(&c.output), "output", "output format: json, table or yaml")
+
+	return fs
+}
+
+// outputFormat flag of --output, unknown format fails when flags are parsed, before command writes anything
+type outputFormat string
+
+func (format *outputFormat) String() string {
+	return string(*format)
+}
+
+func (format *outputFormat) Set(value string) error {
+	switch value {
+	case "json", "table", "yaml":
+		*format = outputFormat(value)
+		return nil
+	}
+
+	return fmt.Errorf("unknown output format '%s'", value)
+}
+
+// stringList flag which can be repeated
+type stringList []string
+
+func (list *stringList) String() string {
+	return strings.Join(*list, ", ")
+}
+
+func (list *stringList) Set(value string) error {
+	*list = append(*list, value)
+	return nil
+}
+
+// readInput read file, "-" is standard input
+func (c *cli) readInput(name string) (string, error) {
+	if name == "-" {
+		b, err := io.ReadAll(c.stdin)
+		return string(b), err
+	}
+
+	b, err := os.ReadFile(name)
+	return string(b), err
+}
+
+// collect fetch results page by page until limit, 0 is all
+func collect(limit int, fetch func(pagination *notion.PaginationRequest) (*notion.PaginationResponse, error)) ([]notion.JSON, error) {
+	results := []notion.JSON{}
+	pagination := &notion.PaginationRequest{PageSize: 100}
+
+	for {
+		if limit > 0 && limit-len(results) < pagination.PageSize {
+			pagination.PageSize = limit - len(results)
+		}
+
+		resp, err := fetch(pagination)
+		if err != nil {
+			return nil, err
+		}
+
+		list := []notion.JSON{}
+		if err := resp.Unmarshal(&list); err != nil {
+			return nil, err
+		}
+		results = append(results, list...)
+
+		if !resp.HasMore || len(resp.NextCursor) == 0 || (limit > 0 && len(results) >= limit) {
+			return results, nil
+		}
+		pagination.StartCursor = resp.NextCursor
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// table rows of table output
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+func newTable(header ...string) *table {
+	return &table{header: header, rows: [][]string{}}
+}
+
+func (t *table) add(columns ...string) {
+	t.rows = append(t.rows, columns)
+}
+
+// print write value in format of --output, table is used for table output
+func (c *cli) print(value interface{}, t *table) error {
+	switch c.output {
+	case "json":
+		b, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(c.stdout, string(b))
+		return err
+	case "yaml":
+		generic, err := toGeneric(value)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(c.stdout, encodeYAML(generic))
+		return err
+	case "table", "":
+		w := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, strings.Join(t.header, "\t"))
+		for _, row := range t.rows {
+			cells := make([]string, len(row))
+			for i, cell := range row {
+				cells[i] = cellText(cell)
+			}
+			fmt.Fprintln(w, strings.Join(cells, "\t"))
+		}
+		return w.Flush()
+	}
+
+	return fmt.Errorf("unknown output format '%s'", c.output)
+}
+
+// toGeneric convert value into maps, slices and scalars of JSON
+func toGeneric(value interface{}) (interface{}, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// cellText make text fit in one cell of table
+func cellText(s string) string {
+	s = strings.NewReplacer("\t", " ", "\r", "", "\n", " ⏎ ").Replace(s)
+
+	runes := []rune(s)
+	if len(runes) > 60 {
+		return string(runes[:59]) + "…"
+	}
+
+	return s
+}
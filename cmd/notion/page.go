@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hunydev/notion"
+)
+
+func pageGet(c *cli, args []string) error {
+	fs := c.flags("page get")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errUsage
+	}
+
+	page, err := c.nt.RetrievePage(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	return c.print(page.JSON, propertyTable(page))
+}
+
+func pageCreate(c *cli, args []string) error {
+	fs := c.flags("page create")
+	parentPage := fs.String("parent", "", "ID of parent page")
+	parentDatabase := fs.String("database", "", "ID of parent database")
+	title := fs.String("title", "", "title of page")
+	props := &stringList{}
+	fs.Var(props, "prop", "property as name=value, only for page in database (repeatable)")
+	markdown := fs.String("markdown", "", "file of content in Markdown, - is standard input")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 || (len(*parentPage) > 0) == (len(*parentDatabase) > 0) {
+		return errUsage
+	}
+
+	assignments, err := parseAssignments(*props)
+	if err != nil {
+		return err
+	}
+
+	var parent *notion.Parent
+	types := map[string]string{}
+	titleName := "title"
+
+	if len(*parentDatabase) > 0 {
+		database, err := c.nt.RetrieveDatabase(*parentDatabase)
+		if err != nil {
+			return err
+		}
+		for _, configuration := range database.Properties() {
+			types[configuration.Name()] = configuration.Type()
+			if configuration.Type() == notion.TypePropertyTitle {
+				titleName = configuration.Name()
+			}
+		}
+		parent = notion.NewParentDatabase(*parentDatabase)
+	} else {
+		if len(assignments) > 0 {
+			return fmt.Errorf("properties other than title can be set only for page in database")
+		}
+		types[titleName] = notion.TypePropertyTitle
+		parent = notion.NewParentPage(*parentPage)
+	}
+
+	properties := []notion.Property{}
+	if len(*title) > 0 {
+		properties = append(properties, notion.NewPropertyTitle(titleName, notion.ParseRichText(*title, nil)))
+	}
+	list, err := assignProperties(assignments, types)
+	if err != nil {
+		return err
+	}
+	properties = append(properties, list...)
+
+	blocks := []notion.Block{}
+	if len(*markdown) > 0 {
+		text, err := c.readInput(*markdown)
+		if err != nil {
+			return err
+		}
+		blocks = notion.ParseMarkdown(text)
+	}
+
+	page, err := c.nt.CreatePage(parent, properties)
+	if err != nil {
+		return err
+	}
+	if len(blocks) > 0 {
+		if _, err := c.nt.AppendBlockTree(page.ID(), blocks); err != nil {
+			return fmt.Errorf("page %s is created, but content is not written: %w", page.ID(), err)
+		}
+	}
+
+	t := newTable("ID", "TITLE")
+	t.add(page.ID(), notion.PlainText(page.Title()))
+
+	return c.print(page.JSON, t)
+}
+
+func pageUpdate(c *cli, args []string) error {
+	fs := c.flags("page update")
+	props := &stringList{}
+	fs.Var(props, "prop", "property as name=value (repeatable)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || len(*props) == 0 {
+		return errUsage
+	}
+
+	assignments, err := parseAssignments(*props)
+	if err != nil {
+		return err
+	}
+
+	page, err := c.nt.RetrievePage(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	types := map[string]string{}
+	for _, property := range page.Properties() {
+		types[property.Name()] = property.Type()
+	}
+
+	properties, err := assignProperties(assignments, types)
+	if err != nil {
+		return err
+	}
+
+	page, err = c.nt.UpdatePageProperties(page.ID(), properties...)
+	if err != nil {
+		return err
+	}
+
+	return c.print(page.JSON, propertyTable(page))
+}
+
+// assignProperties make properties of name=value by type of property of same name
+func assignProperties(assignments [][2]string, types map[string]string) ([]notion.Property, error) {
+	properties := []notion.Property{}
+
+	for _, assignment := range assignments {
+		t, ok := types[assignment[0]]
+		if !ok {
+			return nil, fmt.Errorf("unknown property '%s'", assignment[0])
+		}
+
+		property, err := parseProperty(assignment[0], t, assignment[1])
+		if err != nil {
+			return nil, err
+		}
+		properties = append(properties, property)
+	}
+
+	return properties, nil
+}
+
+func propertyTable(page *notion.Page) *table {
+	values := map[string]notion.Property{}
+	for _, property := range page.Properties() {
+		values[property.Name()] = property
+	}
+
+	t := newTable("NAME", "TYPE", "VALUE")
+	for _, name := range propertyNames(page) {
+		property := values[name]
+		t.add(name, property.Type(), notion.PropertyPlainText(property))
+	}
+
+	return t
+}
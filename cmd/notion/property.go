@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hunydev/notion"
+)
+
+// parseAssignments split name=value of --prop flags
+func parseAssignments(list []string) ([][2]string, error) {
+	assignments := [][2]string{}
+
+	for _, s := range list {
+		i := strings.Index(s, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid property '%s', must be name=value", s)
+		}
+		assignments = append(assignments, [2]string{s[:i], s[i+1:]})
+	}
+
+	return assignments, nil
+}
+
+// parseProperty make property of type from text value.
+// lists (multi_select, people, relation) are comma separated, date is start or start/end
+func parseProperty(Name, Type, Value string) (notion.Property, error) {
+	switch Type {
+	case notion.TypePropertyTitle:
+		return notion.NewPropertyTitle(Name, notion.ParseRichText(Value, nil)), nil
+	case notion.TypePropertyRichText:
+		return notion.NewPropertyRichText(Name, notion.ParseRichText(Value, nil)), nil
+	case notion.TypePropertyNumber:
+		return notion.NewPropertyNumberDecimal(Name, json.Number(strings.TrimSpace(Value)))
+	case notion.TypePropertySelect:
+		return notion.NewPropertySelect(Name, &notion.SelectOption{Name: Value}), nil
+	case notion.TypePropertyMultiSelect:
+		options := []notion.SelectOption{}
+		for _, name := range splitList(Value) {
+			options = append(options, notion.SelectOption{Name: name})
+		}
+		return notion.NewPropertyMultiSelect(Name, options...), nil
+	case notion.TypePropertyDate:
+		date := &notion.Date{}
+		parts := strings.SplitN(Value, "/", 2)
+		date.Start = strings.TrimSpace(parts[0])
+		if len(parts) > 1 {
+			date.End = strings.TrimSpace(parts[1])
+		}
+		for _, value := range parts {
+			if _, _, err := notion.ParseDate(strings.TrimSpace(value), nil); err != nil {
+				return nil, err
+			}
+		}
+		return notion.NewPropertyDate(Name, date), nil
+	case notion.TypePropertyCheckbox:
+		checked, err := strconv.ParseBool(strings.TrimSpace(Value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid checkbox '%s'", Value)
+		}
+		return notion.NewPropertyCheckbox(Name, checked), nil
+	case notion.TypePropertyURL:
+		return notion.NewPropertyURL(Name, Value), nil
+	case notion.TypePropertyEmail:
+		return notion.NewPropertyEmail(Name, Value), nil
+	case notion.TypePropertyPhoneNumber:
+		return notion.NewPropertyPhoneNumber(Name, Value), nil
+	case notion.TypePropertyPeople:
+		users := []notion.User{}
+		for _, id := range splitList(Value) {
+			users = append(users, *notion.NewUser(id))
+		}
+		return notion.NewPropertyPeople(Name, users...), nil
+	case notion.TypePropertyRelation:
+		return notion.NewPropertyRelation(Name, splitList(Value)...), nil
+	}
+
+	return nil, fmt.Errorf("property '%s' of type '%s' can not be written", Name, Type)
+}
+
+func splitList(s string) []string {
+	list := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
@@ -0,0 +1,441 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hunydev/notion"
+)
+
+// relayConfig configuration of relay, written in YAML or JSON
+//
+//	state: .notion-relay.json
+//	dead_letter: dead-letter.ndjson
+//	interval: 1m
+//	routes:
+//	  - url: https://example.com/notion
+//	    secret_env: HOOK_SECRET
+//	    database: <database-id>
+//	  - url: https://example.com/docs
+//	    secret: xxxx
+//	    page: <page-id>
+type relayConfig struct {
+	//State file of watch state, default is .notion-relay.json
+	State string `json:"state"`
+	//DeadLetter file of payloads which could not be delivered, one JSON per line
+	DeadLetter string `json:"dead_letter"`
+	Interval   string `json:"interval"`
+	//Blocks compare blocks of edited pages, see notion.WatchOption
+	Blocks bool `json:"blocks"`
+	//Retries attempts after first delivery, default 5
+	Retries *int         `json:"retries"`
+	Routes  []relayRoute `json:"routes"`
+}
+
+// relayRoute endpoint of events of pages in database or pages under page
+type relayRoute struct {
+	URL       string `json:"url"`
+	Secret    string `json:"secret"`
+	SecretEnv string `json:"secret_env"`
+	Database  string `json:"database"`
+	Page      string `json:"page"`
+	//Events types of events to send, all when empty
+	Events []string `json:"events"`
+}
+
+// relayPayload body of POST, signature is HMAC-SHA256 of "<timestamp>.<body>"
+type relayPayload struct {
+	ID          string       `json:"id"`
+	CreatedTime string       `json:"created_time"`
+	Event       notion.Event `json:"event"`
+}
+
+type deadLetter struct {
+	Time     string       `json:"time"`
+	URL      string       `json:"url"`
+	Attempts int          `json:"attempts"`
+	Error    string       `json:"error"`
+	Payload  relayPayload `json:"payload"`
+}
+
+type relay struct {
+	c       *cli
+	config  *relayConfig
+	client  *http.Client
+	retries int
+	//backoff wait before first retry, doubled for each retry
+	backoff time.Duration
+
+	//parents ID of page or database -> ID of its parent page or database, "" for workspace
+	parents map[string]string
+}
+
+func relayCommand(c *cli, args []string) error {
+	fs := c.flags("relay")
+	configPath := fs.String("config", "notion-relay.yaml", "file of configuration, YAML or JSON")
+	once := fs.Bool("once", false, "poll once and exit")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return errUsage
+	}
+
+	config, err := loadRelayConfig(*configPath)
+	if err != nil {
+		return err
+	}
+
+	r := &relay{
+		c:       c,
+		config:  config,
+		client:  &http.Client{Timeout: c.timeout},
+		retries: 5,
+		backoff: time.Second,
+		parents: map[string]string{},
+	}
+	if config.Retries != nil {
+		r.retries = *config.Retries
+	}
+
+	interval := time.Minute
+	if len(config.Interval) > 0 {
+		if interval, err = time.ParseDuration(config.Interval); err != nil {
+			return fmt.Errorf("interval: %w", err)
+		}
+	}
+
+	state := &notion.WatchState{}
+	if b, err := os.ReadFile(config.State); err == nil {
+		if err := json.Unmarshal(b, state); err != nil {
+			return fmt.Errorf("invalid state %s: %w", config.State, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	option := &notion.WatchOption{
+		Interval:  interval,
+		Blocks:    config.Blocks,
+		State:     state,
+		SaveState: func(state *notion.WatchState) error { return r.saveState(state) },
+		// daemon keeps polling, failed poll is retried at next interval
+		OnError: func(err error) { fmt.Fprintf(c.stderr, "notion relay: %v\n", err) },
+	}
+	// databases are queried directly when every route is of database
+	for _, route := range config.Routes {
+		if len(route.Database) == 0 {
+			option.Databases = nil
+			break
+		}
+		option.Databases = append(option.Databases, route.Database)
+	}
+	watcher := c.nt.NewWatcher(option)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if *once {
+		events, err := watcher.Poll()
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			r.handle(ctx, event)
+		}
+		return r.saveState(watcher.State())
+	}
+
+	fmt.Fprintf(c.stderr, "notion relay: watching every %v, %d routes\n", interval, len(config.Routes))
+	err = watcher.Run(ctx, func(event notion.Event) error {
+		r.handle(ctx, event)
+		return nil
+	})
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+
+	return err
+}
+
+func loadRelayConfig(name string) (*relayConfig, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &relayConfig{}
+	if strings.EqualFold(filepath.Ext(name), ".json") {
+		err = json.Unmarshal(b, config)
+	} else {
+		var v interface{}
+		if v, err = decodeYAML(string(b)); err == nil {
+			var j []byte
+			if j, err = json.Marshal(v); err == nil {
+				err = json.Unmarshal(j, config)
+			}
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", name, err)
+	}
+
+	if len(config.Routes) == 0 {
+		return nil, fmt.Errorf("config %s has no routes", name)
+	}
+	for i := range config.Routes {
+		route := &config.Routes[i]
+		if len(route.URL) == 0 || (len(route.Database) > 0) == (len(route.Page) > 0) {
+			return nil, fmt.Errorf("route %d: url and one of database or page are required", i+1)
+		}
+		if len(route.SecretEnv) > 0 {
+			route.Secret = os.Getenv(route.SecretEnv)
+		}
+		if len(route.Secret) == 0 {
+			return nil, fmt.Errorf("route %d: secret is empty", i+1)
+		}
+		route.Database = normalizeID(route.Database)
+		route.Page = normalizeID(route.Page)
+	}
+	if len(config.State) == 0 {
+		config.State = ".notion-relay.json"
+	}
+	if len(config.DeadLetter) == 0 {
+		config.DeadLetter = "notion-relay-dead-letter.ndjson"
+	}
+
+	return config, nil
+}
+
+func (r *relay) saveState(state *notion.WatchState) error {
+	b, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := r.config.State + ".tmp"
+	if err := os.WriteFile(tmp, b, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, r.config.State)
+}
+
+// handle deliver event to every matching route, failed deliveries are written to dead-letter file
+func (r *relay) handle(ctx context.Context, event notion.Event) {
+	payload := relayPayload{
+		ID:          newDeliveryID(),
+		CreatedTime: notion.TimeFormat(time.Now().UTC()),
+		Event:       event,
+	}
+
+	for _, route := range r.config.Routes {
+		if !r.match(route, event) {
+			continue
+		}
+
+		attempts, err := r.deliver(ctx, route, payload)
+		if err == nil {
+			fmt.Fprintf(r.c.stderr, "%s %s -> %s\n", event.Type, event.PageID, route.URL)
+			continue
+		}
+
+		fmt.Fprintf(r.c.stderr, "notion relay: %s %s -> %s: %v\n", event.Type, event.PageID, route.URL, err)
+		if err := r.writeDeadLetter(deadLetter{
+			Time:     notion.TimeFormat(time.Now().UTC()),
+			URL:      route.URL,
+			Attempts: attempts,
+			Error:    err.Error(),
+			Payload:  payload,
+		}); err != nil {
+			fmt.Fprintf(r.c.stderr, "notion relay: dead letter: %v\n", err)
+		}
+	}
+}
+
+func (r *relay) match(route relayRoute, event notion.Event) bool {
+	if len(route.Events) > 0 {
+		found := false
+		for _, t := range route.Events {
+			found = found || t == event.Type
+		}
+		if !found {
+			return false
+		}
+	}
+
+	parentType := event.Parent.GetString("type")
+	parentID := normalizeID(event.Parent.GetString(parentType))
+
+	if len(route.Database) > 0 {
+		return parentType == notion.TypeParentDatabase && parentID == route.Database
+	}
+
+	// page and its descendants, ancestors are looked up through parents
+	id := normalizeID(event.PageID)
+	r.remember(id, parentType, parentID)
+	for depth := 0; len(id) > 0 && depth < 64; depth++ {
+		if id == route.Page {
+			return true
+		}
+		id = r.parent(id)
+	}
+
+	return false
+}
+
+func (r *relay) remember(id, parentType, parentID string) {
+	if len(parentType) > 0 {
+		r.parents[id] = parentID
+	}
+}
+
+// parent ID of parent page or database of object, "" for workspace or object which can not be retrieved
+func (r *relay) parent(id string) string {
+	parent, ok := r.parents[id]
+	if ok {
+		return parent
+	}
+
+	var p *notion.Parent
+	if page, err := r.c.nt.RetrievePage(id); err == nil {
+		p = page.Parent()
+	} else if database, err := r.c.nt.RetrieveDatabase(id); err == nil {
+		p = database.Parent()
+	}
+	if p != nil {
+		parent = normalizeID(p.ID)
+	}
+
+	r.parents[id] = parent
+
+	return parent
+}
+
+// deliver POST payload to route, retried with exponential backoff on network errors, 429 and 5xx.
+// Retry-After of response is waited when it is longer, waiting stops when ctx is done
+func (r *relay) deliver(ctx context.Context, route relayRoute, payload relayPayload) (int, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, err
+	}
+
+	wait := r.backoff
+	attempts := 0
+	for {
+		attempts++
+		retry, retryAfter, err := r.post(ctx, route, payload.ID, body)
+		if err == nil {
+			return attempts, nil
+		}
+		if !retry || attempts > r.retries {
+			return attempts, err
+		}
+
+		delay := wait
+		if retryAfter > delay {
+			delay = retryAfter
+		}
+		select {
+		case <-ctx.Done():
+			return attempts, fmt.Errorf("%v, retry canceled: %w", err, ctx.Err())
+		case <-time.After(delay):
+		}
+		wait *= 2
+	}
+}
+
+// post send payload once, return whether it can be retried and Retry-After of response
+func (r *relay) post(ctx context.Context, route relayRoute, deliveryID string, body []byte) (bool, time.Duration, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, route.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Notion-Relay-Delivery", deliveryID)
+	req.Header.Set("X-Notion-Relay-Timestamp", timestamp)
+	req.Header.Set("X-Notion-Relay-Signature", "sha256="+signPayload(route.Secret, timestamp, body))
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, 0, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, 0, nil
+	}
+
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, retryAfter(resp.Header.Get("Retry-After")), fmt.Errorf("status %s", resp.Status)
+}
+
+// retryAfter duration of Retry-After header, which is seconds or HTTP date
+func retryAfter(value string) time.Duration {
+	if len(value) == 0 {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return time.Until(t)
+	}
+
+	return 0
+}
+
+func (r *relay) writeDeadLetter(letter deadLetter) error {
+	b, err := json.Marshal(letter)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(r.config.DeadLetter, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// signPayload HMAC-SHA256 of "<timestamp>.<body>" in hex, receiver computes same to verify
+func signPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newDeliveryID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+// normalizeID ID without dashes in lower case, IDs are given with or without dashes
+func normalizeID(id string) string {
+	return strings.ToLower(strings.ReplaceAll(id, "-", ""))
+}
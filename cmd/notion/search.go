@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hunydev/notion"
+)
+
+func search(c *cli, args []string) error {
+	fs := c.flags("search")
+	filter := fs.String("filter", "", "object to search: page or database")
+	sort := fs.String("sort", "", "sort by last edited time: asc or desc")
+	limit := fs.Int("limit", 0, "maximum number of results, 0 is all")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return errUsage
+	}
+
+	object := notion.Object("")
+	switch *filter {
+	case "":
+	case "page":
+		object = notion.ObjectPage
+	case "database":
+		object = notion.ObjectDatabase
+	default:
+		return fmt.Errorf("filter must be page or database")
+	}
+
+	var s *notion.Sort
+	switch *sort {
+	case "":
+	case "asc":
+		s = &notion.Sort{Timestamp: notion.LastEditedTime, Direction: notion.Ascending}
+	case "desc":
+		s = &notion.Sort{Timestamp: notion.LastEditedTime, Direction: notion.Descending}
+	default:
+		return fmt.Errorf("sort must be asc or desc")
+	}
+
+	query := strings.Join(fs.Args(), " ")
+	results, err := collect(*limit, func(pagination *notion.PaginationRequest) (*notion.PaginationResponse, error) {
+		return c.nt.Search(query, pagination, object, s)
+	})
+	if err != nil {
+		return err
+	}
+
+	t := newTable("OBJECT", "ID", "TITLE", "LAST EDITED")
+	for _, j := range results {
+		t.add(j.GetString("object"), j.GetString("id"), objectTitle(j), j.GetString("last_edited_time"))
+	}
+
+	return c.print(results, t)
+}
+
+// objectTitle plain text title of page or database
+func objectTitle(j notion.JSON) string {
+	switch j.GetString("object") {
+	case string(notion.ObjectDatabase):
+		database := &notion.Database{JSON: j}
+		return notion.PlainText(database.Title())
+	case string(notion.ObjectPage):
+		page := &notion.Page{JSON: j}
+		return notion.PlainText(page.Title())
+	}
+
+	return ""
+}
@@ -0,0 +1,537 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hunydev/notion"
+)
+
+// syncBase snapshot of rows after last sync, common ancestor of changes in CSV and Notion
+type syncBase struct {
+	Database string              `json:"database"`
+	Key      string              `json:"key"`
+	Rows     map[string]*syncRow `json:"rows"`
+}
+
+type syncRow struct {
+	PageID         string `json:"page_id"`
+	LastEditedTime string `json:"last_edited_time"`
+	//Values cells by name of property
+	Values map[string]string `json:"values"`
+}
+
+type syncAction struct {
+	Key    string `json:"key"`
+	Action string `json:"action"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type syncer struct {
+	c      *cli
+	codec  *csvCodec
+	base   *syncBase
+	key    string
+	prefer string
+	dryRun bool
+
+	header []string
+	//columns index of CSV column -> name of property
+	columns map[int]string
+	records [][]string
+	//removed indexes of records removed from CSV
+	removed map[int]bool
+
+	actions []syncAction
+}
+
+func syncCommand(c *cli, args []string) error {
+	fs := c.flags("sync")
+	key := fs.String("key", "", "property which identifies rows, default is title")
+	basePath := fs.String("base", "", "file of base snapshot, default is <csv>.sync.json")
+	maps := &stringList{}
+	fs.Var(maps, "map", "column of CSV for property as column=property (repeatable), default is column of same name")
+	prefer := fs.String("prefer", "", "resolve conflicts by csv or notion, conflicts are skipped by default")
+	dryRun := fs.Bool("dry-run", false, "show actions without writing")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return errUsage
+	}
+	if *prefer != "" && *prefer != "csv" && *prefer != "notion" {
+		return fmt.Errorf("prefer must be csv or notion")
+	}
+
+	DatabaseID, file := fs.Arg(0), fs.Arg(1)
+	if len(*basePath) == 0 {
+		*basePath = file + ".sync.json"
+	}
+
+	database, err := c.nt.RetrieveDatabase(DatabaseID)
+	if err != nil {
+		return err
+	}
+
+	s := &syncer{
+		c:       c,
+		codec:   newCSVCodec(c, database),
+		key:     *key,
+		prefer:  *prefer,
+		dryRun:  *dryRun,
+		columns: map[int]string{},
+		removed: map[int]bool{},
+	}
+	if len(s.key) == 0 {
+		s.key = s.codec.titleName
+	}
+	if !s.codec.writable(s.key) {
+		return fmt.Errorf("key '%s' is not writable property", s.key)
+	}
+
+	if err := s.loadBase(*basePath, database.ID()); err != nil {
+		return err
+	}
+	if err := s.loadCSV(file, *maps); err != nil {
+		return err
+	}
+
+	pages, err := collect(0, func(pagination *notion.PaginationRequest) (*notion.PaginationResponse, error) {
+		return c.nt.QueryDatabase(DatabaseID, pagination, nil, nil)
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.sync(pages); err != nil {
+		return err
+	}
+
+	if !s.dryRun {
+		records := [][]string{}
+		for i, record := range s.records {
+			if !s.removed[i] {
+				records = append(records, record)
+			}
+		}
+		if err := writeCSV(file, s.header, records); err != nil {
+			return err
+		}
+		if err := s.saveBase(*basePath); err != nil {
+			return err
+		}
+	}
+
+	t := newTable("KEY", "ACTION", "DETAIL")
+	for _, action := range s.actions {
+		t.add(action.Key, action.Action, action.Detail)
+	}
+
+	return c.print(s.actions, t)
+}
+
+func (s *syncer) loadBase(name, DatabaseID string) error {
+	s.base = &syncBase{Database: DatabaseID, Key: s.key, Rows: map[string]*syncRow{}}
+
+	b, err := os.ReadFile(name)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	base := &syncBase{}
+	if err := json.Unmarshal(b, base); err != nil {
+		return fmt.Errorf("invalid base %s: %w", name, err)
+	}
+	if normalizeID(base.Database) != normalizeID(DatabaseID) || base.Key != s.key {
+		return fmt.Errorf("base %s is sync of database %s by '%s', use --base for other sync", name, base.Database, base.Key)
+	}
+	if base.Rows != nil {
+		s.base = base
+	}
+
+	return nil
+}
+
+func (s *syncer) saveBase(name string) error {
+	b, err := json.MarshalIndent(s.base, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := name + ".tmp"
+	if err := os.WriteFile(tmp, b, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, name)
+}
+
+// loadCSV read CSV file, new file has columns of all properties
+func (s *syncer) loadCSV(name string, maps []string) error {
+	header, records, err := readCSV(name)
+	if os.IsNotExist(err) {
+		header = []string{s.key}
+		names := []string{}
+		for name := range s.codec.schema {
+			if name != s.key {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		header = append(header, names...)
+	} else if err != nil {
+		return err
+	}
+	s.header = header
+	s.records = records
+
+	mapping := map[string]string{}
+	for _, m := range maps {
+		i := strings.Index(m, "=")
+		if i <= 0 {
+			return fmt.Errorf("invalid map '%s', must be column=property", m)
+		}
+		mapping[m[:i]] = m[i+1:]
+	}
+
+	for i, column := range header {
+		property, mapped := mapping[column]
+		if !mapped {
+			property = column
+		}
+		if _, ok := s.codec.schema[property]; ok {
+			s.columns[i] = property
+		} else if mapped {
+			return fmt.Errorf("unknown property '%s' of column '%s'", property, column)
+		}
+	}
+
+	for _, property := range s.columns {
+		if property == s.key {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("CSV has no column of key '%s'", s.key)
+}
+
+// values cells of record by property
+func (s *syncer) values(record []string) map[string]string {
+	values := map[string]string{}
+
+	for i, property := range s.columns {
+		if i < len(record) {
+			values[property] = record[i]
+		} else {
+			values[property] = ""
+		}
+	}
+
+	return values
+}
+
+// set write cells of properties into record
+func (s *syncer) set(record []string, values map[string]string) []string {
+	for len(record) < len(s.header) {
+		record = append(record, "")
+	}
+	for i, property := range s.columns {
+		record[i] = values[property]
+	}
+
+	return record
+}
+
+func (s *syncer) sync(results []notion.JSON) error {
+	pages := map[string]*notion.Page{}
+	for _, j := range results {
+		page := &notion.Page{JSON: j}
+		key := strings.TrimSpace(s.codec.row(page)[s.key])
+		if len(key) == 0 {
+			s.act(page.ID(), "skipped", "key is empty")
+			continue
+		}
+		if _, ok := pages[key]; ok {
+			return fmt.Errorf("key '%s' is duplicated in database", key)
+		}
+		pages[key] = page
+	}
+
+	rows := map[string]int{}
+	for i, record := range s.records {
+		key := strings.TrimSpace(s.values(record)[s.key])
+		if len(key) == 0 {
+			s.act(fmt.Sprintf("row %d", i+2), "skipped", "key is empty")
+			continue
+		}
+		if _, ok := rows[key]; ok {
+			return fmt.Errorf("key '%s' is duplicated in CSV", key)
+		}
+		rows[key] = i
+	}
+
+	keys := []string{}
+	seen := map[string]bool{}
+	for _, list := range [][]string{mapKeys(s.base.Rows), mapKeys(pages), mapKeys(rows)} {
+		for _, key := range list {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		base := s.base.Rows[key]
+		page, inNotion := pages[key]
+		index, inCSV := rows[key]
+
+		var err error
+		switch {
+		case inNotion && inCSV:
+			err = s.merge(key, base, page, index)
+		case inNotion && base == nil:
+			s.pull(key, page, -1, "added to CSV")
+		case inNotion:
+			// removed from CSV
+			if s.editedInNotion(page, base) && s.prefer != "csv" {
+				if s.prefer == "notion" {
+					s.pull(key, page, -1, "added to CSV again, edited in Notion")
+					break
+				}
+				s.act(key, "conflict", "removed from CSV, edited in Notion")
+				break
+			}
+			err = s.archive(key, page)
+		case inCSV && base == nil:
+			err = s.create(key, index)
+		case inCSV:
+			// archived in Notion
+			if !equalValues(s.values(s.records[index]), base.Values, s.columns) && s.prefer != "notion" {
+				if s.prefer == "csv" {
+					err = s.create(key, index)
+					break
+				}
+				s.act(key, "conflict", "archived in Notion, edited in CSV")
+				break
+			}
+			s.removed[index] = true
+			delete(s.base.Rows, key)
+			s.act(key, "removed from CSV", "archived in Notion")
+		default:
+			delete(s.base.Rows, key)
+		}
+		if err != nil {
+			s.act(key, "error", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// merge three-way merge of cells of row, changes of different properties are combined
+func (s *syncer) merge(key string, base *syncRow, page *notion.Page, index int) error {
+	csvValues := s.values(s.records[index])
+	notionValues := s.codec.row(page)
+	baseValues := map[string]string{}
+	if base != nil {
+		baseValues = base.Values
+	}
+
+	toNotion := map[string]string{}
+	toCSV := map[string]string{}
+	conflicts := []string{}
+
+	for _, property := range s.columns {
+		cv, nv := csvValues[property], notionValues[property]
+		if cv == nv {
+			continue
+		}
+
+		b, known := baseValues[property]
+		csvChanged := !known || cv != b
+		notionChanged := !known || nv != b || !s.codec.writable(property)
+
+		switch {
+		case !s.codec.writable(property) || (notionChanged && !csvChanged):
+			toCSV[property] = nv
+		case csvChanged && !notionChanged:
+			toNotion[property] = cv
+		case s.prefer == "csv":
+			toNotion[property] = cv
+		case s.prefer == "notion":
+			toCSV[property] = nv
+		default:
+			conflicts = append(conflicts, property)
+		}
+	}
+	sort.Strings(conflicts)
+
+	if len(conflicts) > 0 {
+		s.act(key, "conflict", "changed in CSV and Notion: "+strings.Join(conflicts, ", "))
+		return nil
+	}
+
+	if len(toNotion) > 0 {
+		properties := []notion.Property{}
+		for _, property := range sortedKeys(toNotion) {
+			p, err := s.codec.property(property, toNotion[property])
+			if err != nil {
+				return err
+			}
+			properties = append(properties, p)
+		}
+
+		if !s.dryRun {
+			updated, err := s.c.nt.UpdatePageProperties(page.ID(), properties...)
+			if err != nil {
+				return err
+			}
+			page = updated
+		}
+		s.act(key, "updated in Notion", strings.Join(sortedKeys(toNotion), ", "))
+	}
+	if len(toCSV) > 0 {
+		s.act(key, "updated in CSV", strings.Join(sortedKeys(toCSV), ", "))
+	}
+
+	s.pull(key, page, index, "")
+
+	return nil
+}
+
+// pull write values of page into record of index, -1 appends record
+func (s *syncer) pull(key string, page *notion.Page, index int, action string) {
+	values := s.codec.row(page)
+
+	if index < 0 {
+		s.records = append(s.records, s.set(nil, values))
+	} else if !s.dryRun {
+		s.records[index] = s.set(s.records[index], values)
+	}
+
+	s.base.Rows[key] = &syncRow{PageID: page.ID(), LastEditedTime: page.LastEditedTime(), Values: s.synced(values)}
+	if len(action) > 0 {
+		s.act(key, action, "")
+	}
+}
+
+// editedInNotion check page is edited since sync. last edited time has only minute precision,
+// so writable cells are compared with base when it is same
+func (s *syncer) editedInNotion(page *notion.Page, base *syncRow) bool {
+	if len(base.LastEditedTime) > 0 && page.LastEditedTime() != base.LastEditedTime {
+		return true
+	}
+
+	values := s.codec.row(page)
+	for _, property := range s.columns {
+		if s.codec.writable(property) && values[property] != base.Values[property] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// synced values of properties of columns
+func (s *syncer) synced(values map[string]string) map[string]string {
+	synced := map[string]string{}
+	for _, property := range s.columns {
+		synced[property] = values[property]
+	}
+
+	return synced
+}
+
+func (s *syncer) create(key string, index int) error {
+	values := s.values(s.records[index])
+
+	properties := []notion.Property{}
+	for _, property := range sortedKeys(values) {
+		if !s.codec.writable(property) || len(strings.TrimSpace(values[property])) == 0 {
+			continue
+		}
+		p, err := s.codec.property(property, values[property])
+		if err != nil {
+			return err
+		}
+		properties = append(properties, p)
+	}
+
+	s.act(key, "created in Notion", "")
+	if s.dryRun {
+		return nil
+	}
+
+	page, err := s.c.nt.CreatePage(notion.NewParentDatabase(s.base.Database), properties)
+	if err != nil {
+		return err
+	}
+	s.pull(key, page, index, "")
+
+	return nil
+}
+
+func (s *syncer) archive(key string, page *notion.Page) error {
+	s.act(key, "archived in Notion", "removed from CSV")
+	if s.dryRun {
+		return nil
+	}
+
+	if _, err := s.c.nt.DeleteBlock(page.ID()); err != nil {
+		return err
+	}
+	delete(s.base.Rows, key)
+
+	return nil
+}
+
+func (s *syncer) act(key, action, detail string) {
+	s.actions = append(s.actions, syncAction{Key: key, Action: action, Detail: detail})
+}
+
+func equalValues(a, b map[string]string, columns map[int]string) bool {
+	for _, property := range columns {
+		if a[property] != b[property] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func mapKeys(m interface{}) []string {
+	keys := []string{}
+
+	switch x := m.(type) {
+	case map[string]*syncRow:
+		for k := range x {
+			keys = append(keys, k)
+		}
+	case map[string]*notion.Page:
+		for k := range x {
+			keys = append(keys, k)
+		}
+	case map[string]int:
+		for k := range x {
+			keys = append(keys, k)
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"github.com/hunydev/notion"
+)
+
+func usersList(c *cli, args []string) error {
+	fs := c.flags("users list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return errUsage
+	}
+
+	results, err := collect(0, c.nt.ListAllUsers)
+	if err != nil {
+		return err
+	}
+
+	t := newTable("ID", "TYPE", "NAME", "EMAIL")
+	for _, j := range results {
+		user := &notion.User{ID: j.GetString("id"), JSON: j}
+		t.add(user.ID, j.GetString("type"), user.Name(), user.Email())
+	}
+
+	return c.print(results, t)
+}
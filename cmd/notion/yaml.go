@@ -0,0 +1,339 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// yamlPlain scalar which can be written without quotes
+var yamlPlain = regexp.MustCompile(`^[A-Za-z_/][A-Za-z0-9_ ./@+()-]*$`)
+
+// yamlReserved plain scalars which YAML reads as other than string
+var yamlReserved = map[string]bool{
+	"true": true, "false": true, "yes": true, "no": true, "on": true, "off": true,
+	"null": true, "y": true, "n": true,
+}
+
+// encodeYAML write value of maps, slices and scalars of JSON as YAML document. keys of map are sorted
+func encodeYAML(v interface{}) string {
+	b := &strings.Builder{}
+
+	switch x := v.(type) {
+	case map[string]interface{}:
+		if len(x) == 0 {
+			return "{}\n"
+		}
+	case []interface{}:
+		if len(x) == 0 {
+			return "[]\n"
+		}
+	default:
+		return yamlScalar(x) + "\n"
+	}
+
+	writeYAML(b, v, "")
+
+	return b.String()
+}
+
+func writeYAML(b *strings.Builder, v interface{}, indent string) {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			b.WriteString(indent + yamlScalar(k) + ":")
+			writeYAMLChild(b, x[k], indent)
+		}
+	case []interface{}:
+		for _, item := range x {
+			if m, ok := item.(map[string]interface{}); ok && len(m) > 0 {
+				// first key of map is written on line of "-"
+				item := &strings.Builder{}
+				writeYAML(item, m, indent+"  ")
+				b.WriteString(indent + "- " + strings.TrimPrefix(item.String(), indent+"  "))
+				continue
+			}
+
+			b.WriteString(indent + "-")
+			writeYAMLChild(b, item, indent)
+		}
+	}
+}
+
+// writeYAMLChild write value after "key:" or "-", collections are written in block under it
+func writeYAMLChild(b *strings.Builder, v interface{}, indent string) {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		if len(x) == 0 {
+			b.WriteString(" {}\n")
+			return
+		}
+	case []interface{}:
+		if len(x) == 0 {
+			b.WriteString(" []\n")
+			return
+		}
+	default:
+		b.WriteString(" " + yamlScalar(x) + "\n")
+		return
+	}
+
+	b.WriteString("\n")
+	writeYAML(b, v, indent+"  ")
+}
+
+func yamlScalar(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(x)
+	case json.Number:
+		return x.String()
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case string:
+		if yamlPlain.MatchString(x) && !yamlReserved[strings.ToLower(x)] && !strings.HasSuffix(x, " ") {
+			return x
+		}
+		return strconv.Quote(x)
+	}
+
+	return strconv.Quote(fmt.Sprint(v))
+}
+
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+}
+
+// decodeYAML read block maps, block lists and scalars, the subset written by encodeYAML and common in front matter.
+// anchors, multi-line strings and nested flow collections are not supported
+func decodeYAML(source string) (interface{}, error) {
+	lines := []yamlLine{}
+	for i, line := range strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n") {
+		text := strings.TrimLeft(line, " ")
+		if len(strings.TrimSpace(text)) == 0 || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if strings.HasPrefix(text, "\t") {
+			return nil, fmt.Errorf("line %d: tab is not allowed for indent", i+1)
+		}
+		lines = append(lines, yamlLine{number: i + 1, indent: len(line) - len(text), text: strings.TrimRight(text, " ")})
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+
+	v, next, err := decodeYAMLBlock(lines, 0, lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if next < len(lines) {
+		return nil, fmt.Errorf("line %d: unexpected indent", lines[next].number)
+	}
+
+	return v, nil
+}
+
+func isYAMLListItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func decodeYAMLBlock(lines []yamlLine, i int, indent int) (interface{}, int, error) {
+	if isYAMLListItem(lines[i].text) {
+		list := []interface{}{}
+		for i < len(lines) && lines[i].indent == indent && isYAMLListItem(lines[i].text) {
+			rest := strings.TrimSpace(lines[i].text[1:])
+
+			switch {
+			case len(rest) == 0:
+				if i+1 < len(lines) && lines[i+1].indent > indent {
+					v, next, err := decodeYAMLBlock(lines, i+1, lines[i+1].indent)
+					if err != nil {
+						return nil, 0, err
+					}
+					list = append(list, v)
+					i = next
+					continue
+				}
+				list = append(list, nil)
+				i++
+			case yamlKeyEnd(rest) >= 0:
+				// map starts on line of "-", rest of map is indented under it
+				inner := indent + len(lines[i].text) - len(rest)
+				copied := append([]yamlLine{}, lines...)
+				copied[i] = yamlLine{number: lines[i].number, indent: inner, text: rest}
+				v, next, err := decodeYAMLBlock(copied, i, inner)
+				if err != nil {
+					return nil, 0, err
+				}
+				list = append(list, v)
+				i = next
+			default:
+				v, err := yamlValue(rest)
+				if err != nil {
+					return nil, 0, fmt.Errorf("line %d: %w", lines[i].number, err)
+				}
+				list = append(list, v)
+				i++
+			}
+		}
+		return list, i, nil
+	}
+
+	m := map[string]interface{}{}
+	for i < len(lines) && lines[i].indent == indent {
+		line := lines[i]
+		end := yamlKeyEnd(line.text)
+		if end < 0 {
+			return nil, 0, fmt.Errorf("line %d: expected key", line.number)
+		}
+
+		key := strings.TrimSpace(line.text[:end])
+		if strings.HasPrefix(key, `"`) || strings.HasPrefix(key, "'") {
+			k, err := yamlValue(key)
+			if err != nil {
+				return nil, 0, fmt.Errorf("line %d: %w", line.number, err)
+			}
+			key = fmt.Sprint(k)
+		}
+		rest := strings.TrimSpace(line.text[end+1:])
+		i++
+
+		if len(rest) > 0 {
+			v, err := yamlValue(rest)
+			if err != nil {
+				return nil, 0, fmt.Errorf("line %d: %w", line.number, err)
+			}
+			m[key] = v
+			continue
+		}
+
+		// block under key, list may be at same indent as key
+		if i < len(lines) && (lines[i].indent > indent || (lines[i].indent == indent && isYAMLListItem(lines[i].text))) {
+			v, next, err := decodeYAMLBlock(lines, i, lines[i].indent)
+			if err != nil {
+				return nil, 0, err
+			}
+			m[key] = v
+			i = next
+			continue
+		}
+		m[key] = nil
+	}
+	if i < len(lines) && lines[i].indent > indent {
+		return nil, 0, fmt.Errorf("line %d: unexpected indent", lines[i].number)
+	}
+
+	return m, i, nil
+}
+
+// yamlKeyEnd index of colon which ends key, -1 if text is not "key: value"
+func yamlKeyEnd(text string) int {
+	start := 0
+	if strings.HasPrefix(text, `"`) || strings.HasPrefix(text, "'") {
+		end := yamlQuoteEnd(text)
+		if end < 0 {
+			return -1
+		}
+		start = end + 1
+	}
+
+	for i := start; i < len(text); i++ {
+		if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+			return i
+		}
+		if start > 0 && text[i] != ' ' {
+			return -1
+		}
+	}
+
+	return -1
+}
+
+// yamlQuoteEnd index of quote which closes quoted text
+func yamlQuoteEnd(text string) int {
+	quote := text[0]
+	for i := 1; i < len(text); i++ {
+		switch {
+		case quote == '"' && text[i] == '\\':
+			i++
+		case text[i] == quote:
+			if quote == '\'' && i+1 < len(text) && text[i+1] == '\'' {
+				i++
+				continue
+			}
+			return i
+		}
+	}
+
+	return -1
+}
+
+func yamlValue(text string) (interface{}, error) {
+	switch {
+	case strings.HasPrefix(text, `"`):
+		end := yamlQuoteEnd(text)
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated string")
+		}
+		return strconv.Unquote(text[:end+1])
+	case strings.HasPrefix(text, "'"):
+		end := yamlQuoteEnd(text)
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated string")
+		}
+		return strings.ReplaceAll(text[1:end], "''", "'"), nil
+	case strings.HasPrefix(text, "["):
+		if !strings.HasSuffix(text, "]") {
+			return nil, fmt.Errorf("unterminated list")
+		}
+		list := []interface{}{}
+		inner := strings.TrimSpace(text[1 : len(text)-1])
+		if len(inner) == 0 {
+			return list, nil
+		}
+		for _, item := range strings.Split(inner, ",") {
+			v, err := yamlValue(strings.TrimSpace(item))
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	case text == "{}":
+		return map[string]interface{}{}, nil
+	}
+
+	if i := strings.Index(text, " #"); i >= 0 {
+		text = strings.TrimSpace(text[:i])
+	}
+
+	switch strings.ToLower(text) {
+	case "null", "~":
+		return nil, nil
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	if numberText.MatchString(text) {
+		return json.Number(text), nil
+	}
+
+	return text, nil
+}
+
+// numberText plain scalar read as number
+var numberText = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeYAML(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		yaml  string
+	}{
+		{value: nil, yaml: "null\n"},
+		{value: "text", yaml: "text\n"},
+		{value: map[string]interface{}{}, yaml: "{}\n"},
+		{value: []interface{}{}, yaml: "[]\n"},
+		{
+			value: map[string]interface{}{"title": "Notes", "done": true, "count": json.Number("1.50")},
+			yaml:  "count: 1.50\ndone: true\ntitle: Notes\n",
+		},
+		{
+			value: map[string]interface{}{"tags": []interface{}{"a", "b"}, "empty": []interface{}{}},
+			yaml:  "empty: []\ntags:\n  - a\n  - b\n",
+		},
+		{
+			value: map[string]interface{}{"reserved": "yes", "colon": "a: b", "number": "12", "space": "end "},
+			yaml:  "colon: \"a: b\"\nnumber: \"12\"\nreserved: \"yes\"\nspace: \"end \"\n",
+		},
+	}
+
+	for _, test := range tests {
+		if got := encodeYAML(test.value); got != test.yaml {
+			t.Errorf("%v: got %q, want %q", test.value, got, test.yaml)
+		}
+	}
+}
+
+func TestDecodeYAML(t *testing.T) {
+	tests := []struct {
+		yaml  string
+		value interface{}
+		err   bool
+	}{
+		{yaml: "", value: nil},
+		{yaml: "# comment\n", value: nil},
+		{
+			yaml:  "title: Notes\ndone: true\nempty:\nnone: ~\ncount: 3\nprice: 1.50\n",
+			value: map[string]interface{}{"title": "Notes", "done": true, "empty": nil, "none": nil, "count": json.Number("3"), "price": json.Number("1.50")},
+		},
+		{
+			yaml:  "tags:\n- a\n- 'it''s'\nflow: [x, 2]\n",
+			value: map[string]interface{}{"tags": []interface{}{"a", "it's"}, "flow": []interface{}{"x", json.Number("2")}},
+		},
+		{
+			yaml:  "rows:\n  - name: a\n    size: 1\n  - name: b\n",
+			value: map[string]interface{}{"rows": []interface{}{map[string]interface{}{"name": "a", "size": json.Number("1")}, map[string]interface{}{"name": "b"}}},
+		},
+		{
+			yaml:  "\"quoted: key\": \"line\\nbreak\"\ncomment: value # note\nversion: 1.2.3\n",
+			value: map[string]interface{}{"quoted: key": "line\nbreak", "comment": "value", "version": "1.2.3"},
+		},
+		{yaml: "a: 1\n  b: 2\n", err: true},
+		{yaml: "a: \"open\n", err: true},
+		{yaml: "just text\n", err: true},
+		{yaml: "a:\n\t- b\n", err: true},
+	}
+
+	for _, test := range tests {
+		got, err := decodeYAML(test.yaml)
+		if test.err {
+			if err == nil {
+				t.Errorf("%q: expected error, got %v", test.yaml, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", test.yaml, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.value) {
+			t.Errorf("%q: got %#v, want %#v", test.yaml, got, test.value)
+		}
+	}
+}
+
+func TestYAMLRoundTrip(t *testing.T) {
+	for _, value := range []interface{}{
+		map[string]interface{}{
+			"title":  "Release: 1.0",
+			"tags":   []interface{}{"work", "urgent"},
+			"due":    map[string]interface{}{"start": "2021-06-01", "end": nil},
+			"rows":   []interface{}{map[string]interface{}{"name": "a", "done": false}},
+			"amount": json.Number("1200.50"),
+			"quote":  "say \"hi\"",
+		},
+		[]interface{}{"a", json.Number("-1"), true, nil, []interface{}{"nested"}},
+	} {
+		got, err := decodeYAML(encodeYAML(value))
+		if err != nil {
+			t.Errorf("%v: %v", value, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, value) {
+			t.Errorf("got %#v, want %#v", got, value)
+		}
+	}
+}
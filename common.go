@@ -58,7 +58,7 @@ func (response *PaginationResponse) Unmarshal(v interface{}) error {
 		return err
 	}
 
-	return json.Unmarshal(b, v)
+	return decodeJSON(b, v)
 }
 
 func (response *PaginationResponse) Users() ([]User, error) {
@@ -149,6 +149,8 @@ func (response *PaginationResponse) Databases() ([]Database, error) {
 type Date struct {
 	Start string `json:"start"`
 	End   string `json:"end"`
+	//TimeZone IANA name of time zone, Start and End are written without offset when it is set
+	TimeZone string `json:"time_zone,omitempty"`
 }
 
 type RichText struct {
@@ -159,9 +161,9 @@ func NewRichText(PlainText string) *RichText {
 	return &RichText{
 		JSON: JSON{
 			"type": "text",
-			"text": &Text{
-				Content: PlainText,
-				Link:    nil,
+			"text": JSON{
+				"content": PlainText,
+				"link":    nil,
 			},
 			"plain_text": PlainText,
 			"href":       nil,
@@ -212,7 +214,7 @@ func (rt *RichText) GetAnnotations() (*Annotations, error) {
 
 func (rt *RichText) SetAnnotations(annotations *Annotations) {
 	if annotations != nil {
-		rt.JSON["annotations"] = *annotations
+		rt.JSON.Set("annotations", *annotations)
 	}
 }
 
@@ -311,14 +313,21 @@ func (rt *RichText) SetEquation(equation *Equation) {
 
 func (rt *RichText) GetMentionUser() (*User, error) {
 	user := &User{}
-	if err := rt.getMention("user", user); err != nil {
+	if err := rt.getMention("user", &user.JSON); err != nil {
 		return nil, err
 	}
+	user.ID = user.JSON.GetString("id")
+
 	return user, nil
 }
 
 func (rt *RichText) SetMentionUser(user *User) error {
-	return rt.setMention("user", user)
+	j := JSON{
+		"object": "user",
+		"id":     user.ID,
+	}
+
+	return rt.setMention("user", j)
 }
 
 func (rt *RichText) GetMentionPage() (*Page, error) {
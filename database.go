@@ -1,6 +1,7 @@
 package notion
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 )
@@ -25,6 +26,23 @@ func (database *Database) LastEditedTime() string {
 	return database.JSON.GetString("last_edited_time")
 }
 
+// Parent return parent of database, nil if API version does not provide it
+func (database *Database) Parent() *Parent {
+	j, ok := database.JSON.GetJSON("parent")
+	if !ok {
+		return nil
+	}
+
+	switch j.GetString("type") {
+	case TypeParentPage:
+		return NewParentPage(j.GetString(TypeParentPage))
+	case TypeParentWorkspace:
+		return NewParentWorkspace()
+	}
+
+	return nil
+}
+
 func (database *Database) Title() []RichText {
 	list := []RichText{}
 
@@ -53,7 +71,7 @@ func (database *Database) Properties() []Configuration {
 
 	for k, v := range j {
 		jj := JSON{}
-		if j.Marshal(v) != nil {
+		if jj.Marshal(v) != nil {
 			continue
 		}
 
@@ -65,7 +83,7 @@ func (database *Database) Properties() []Configuration {
 		properties = append(properties, configuration)
 	}
 
-	return nil
+	return properties
 }
 
 type Configuration interface {
@@ -109,6 +127,18 @@ func AssignConfiguration(name string, json JSON) (Configuration, error) {
 		configuration = &ConfigurationEmail{&BaseConfiguration{&BaseProperty{name: name, JSON: json}}}
 	case TypePropertyPhoneNumber:
 		configuration = &ConfigurationPhoneNumber{&BaseConfiguration{&BaseProperty{name: name, JSON: json}}}
+	case TypePropertyRelation:
+		configuration = &ConfigurationRelation{&BaseConfiguration{&BaseProperty{name: name, JSON: json}}}
+	case TypePropertyRollup:
+		configuration = &ConfigurationRollup{&BaseConfiguration{&BaseProperty{name: name, JSON: json}}}
+	case TypePropertyCreatedTime:
+		configuration = &ConfigurationCreatedTime{&BaseConfiguration{&BaseProperty{name: name, JSON: json}}}
+	case TypePropertyLastEditedTime:
+		configuration = &ConfigurationLastEditedTime{&BaseConfiguration{&BaseProperty{name: name, JSON: json}}}
+	case TypePropertyCreatedBy:
+		configuration = &ConfigurationCreatedBy{&BaseConfiguration{&BaseProperty{name: name, JSON: json}}}
+	case TypePropertyLastEditedBy:
+		configuration = &ConfigurationLastEditedBy{&BaseConfiguration{&BaseProperty{name: name, JSON: json}}}
 	default:
 		return nil, fmt.Errorf("invalid type: '%s'", t)
 	}
@@ -158,6 +188,11 @@ func (configuration *ConfigurationNumber) Format() string {
 	return j.GetString("format")
 }
 
+// FormatValue render number in format of configuration, see FormatDecimal
+func (configuration *ConfigurationNumber) FormatValue(Value json.Number) string {
+	return FormatDecimal(Value, configuration.Format())
+}
+
 type SelectOptionsConfiguration struct {
 	*BaseConfiguration
 }
@@ -231,3 +266,87 @@ func (configuration *ConfigurationFormula) Expression() string {
 
 	return j.GetString("expression")
 }
+
+type ConfigurationRelation struct {
+	*BaseConfiguration
+}
+
+// DatabaseID return ID of related database
+func (configuration *ConfigurationRelation) DatabaseID() string {
+	j, ok := configuration.JSON.GetJSON(configuration.Type())
+	if !ok {
+		return ""
+	}
+
+	return j.GetString("database_id")
+}
+
+// SyncedPropertyName return name of relation property in related database
+func (configuration *ConfigurationRelation) SyncedPropertyName() string {
+	j, ok := configuration.JSON.GetJSON(configuration.Type())
+	if !ok {
+		return ""
+	}
+
+	return j.GetString("synced_property_name")
+}
+
+func (configuration *ConfigurationRelation) SyncedPropertyID() string {
+	j, ok := configuration.JSON.GetJSON(configuration.Type())
+	if !ok {
+		return ""
+	}
+
+	return j.GetString("synced_property_id")
+}
+
+type ConfigurationRollup struct {
+	*BaseConfiguration
+}
+
+func (configuration *ConfigurationRollup) get(name string) string {
+	j, ok := configuration.JSON.GetJSON(configuration.Type())
+	if !ok {
+		return ""
+	}
+
+	return j.GetString(name)
+}
+
+// RelationPropertyName return name of relation property which is rolled up
+func (configuration *ConfigurationRollup) RelationPropertyName() string {
+	return configuration.get("relation_property_name")
+}
+
+func (configuration *ConfigurationRollup) RelationPropertyID() string {
+	return configuration.get("relation_property_id")
+}
+
+// RollupPropertyName return name of property in related database which is rolled up
+func (configuration *ConfigurationRollup) RollupPropertyName() string {
+	return configuration.get("rollup_property_name")
+}
+
+func (configuration *ConfigurationRollup) RollupPropertyID() string {
+	return configuration.get("rollup_property_id")
+}
+
+func (configuration *ConfigurationRollup) Function() string {
+	return configuration.get("function")
+}
+
+type ConfigurationCreatedTime struct {
+	*BaseConfiguration
+}
+
+type ConfigurationLastEditedTime struct {
+	*BaseConfiguration
+}
+
+type ConfigurationCreatedBy struct {
+	*BaseConfiguration
+}
+
+type ConfigurationLastEditedBy struct {
+	*BaseConfiguration
+}
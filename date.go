@@ -0,0 +1,171 @@
+package notion
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DateLayout layout of date-only value
+	DateLayout = "2006-01-02"
+	// DateTimeLayout layout of date with time, as Notion writes it
+	DateTimeLayout = "2006-01-02T15:04:05.000Z07:00"
+
+	dateTimeLocalLayout = "2006-01-02T15:04:05.000"
+)
+
+// dateLayoutsOffset layouts which include offset of time zone
+var dateLayoutsOffset = []string{
+	DateTimeLayout,
+	time.RFC3339Nano,
+	"2006-01-02T15:04Z07:00",
+}
+
+// dateLayoutsLocal layouts without offset, read in location
+var dateLayoutsLocal = []string{
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+	DateLayout,
+}
+
+// ParseDate parse date-only or date with time value of Notion.
+// value without offset (date-only, or datetime of date with time_zone) is read in Location, UTC if Location is nil
+func ParseDate(Value string, Location *time.Location) (Time time.Time, HasTime bool, err error) {
+	if Location == nil {
+		Location = time.UTC
+	}
+
+	for _, layout := range dateLayoutsOffset {
+		if t, err := time.Parse(layout, Value); err == nil {
+			return t, true, nil
+		}
+	}
+
+	for _, layout := range dateLayoutsLocal {
+		if t, err := time.ParseInLocation(layout, Value, Location); err == nil {
+			return t, layout != DateLayout, nil
+		}
+	}
+
+	return time.Time{}, false, fmt.Errorf("invalid date: '%s'", Value)
+}
+
+// FormatDate format date-only value
+func FormatDate(t time.Time) string {
+	return t.Format(DateLayout)
+}
+
+// FormatDateTime format date with time, offset of location of t is kept
+func FormatDateTime(t time.Time) string {
+	return t.Format(DateTimeLayout)
+}
+
+// NewDate date with time
+func NewDate(Start time.Time) *Date {
+	return &Date{Start: FormatDateTime(Start)}
+}
+
+// NewDateRange range of dates with time
+func NewDateRange(Start, End time.Time) *Date {
+	return &Date{Start: FormatDateTime(Start), End: FormatDateTime(End)}
+}
+
+// NewDateOnly date without time, clock of Start is ignored
+func NewDateOnly(Start time.Time) *Date {
+	return &Date{Start: FormatDate(Start)}
+}
+
+// NewDateOnlyRange range of dates without time
+func NewDateOnlyRange(Start, End time.Time) *Date {
+	return &Date{Start: FormatDate(Start), End: FormatDate(End)}
+}
+
+// InTimeZone return copy of date with time converted into time zone, e.g. "America/New_York".
+// Start and End are written without offset and TimeZone is set. date-only value is not changed except TimeZone
+func (date *Date) InTimeZone(TimeZone string) (*Date, error) {
+	location, err := time.LoadLocation(TimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	converted := &Date{TimeZone: TimeZone}
+
+	convert := func(value string) (string, error) {
+		if len(value) == 0 {
+			return "", nil
+		}
+		t, hasTime, err := ParseDate(value, date.Location())
+		if err != nil {
+			return "", err
+		}
+		if !hasTime {
+			return value, nil
+		}
+
+		return t.In(location).Format(dateTimeLocalLayout), nil
+	}
+
+	if converted.Start, err = convert(date.Start); err != nil {
+		return nil, err
+	}
+	if converted.End, err = convert(date.End); err != nil {
+		return nil, err
+	}
+
+	return converted, nil
+}
+
+// HasTime check start of date has time
+func (date *Date) HasTime() bool {
+	return strings.Contains(date.Start, "T")
+}
+
+// IsRange check date has end
+func (date *Date) IsRange() bool {
+	return len(date.End) > 0
+}
+
+// Location return location of TimeZone, UTC if TimeZone is empty or unknown
+func (date *Date) Location() *time.Location {
+	if len(date.TimeZone) > 0 {
+		if location, err := time.LoadLocation(date.TimeZone); err == nil {
+			return location
+		}
+	}
+
+	return time.UTC
+}
+
+func (date *Date) StartTime() (time.Time, error) {
+	t, _, err := ParseDate(date.Start, date.Location())
+
+	return t, err
+}
+
+func (date *Date) EndTime() (time.Time, error) {
+	if !date.IsRange() {
+		return time.Time{}, fmt.Errorf("date has no end")
+	}
+
+	t, _, err := ParseDate(date.End, date.Location())
+
+	return t, err
+}
+
+// MarshalJSON write empty End as null, Notion rejects empty string
+func (date Date) MarshalJSON() ([]byte, error) {
+	j := map[string]interface{}{
+		"start": date.Start,
+		"end":   nil,
+	}
+	if len(date.End) > 0 {
+		j["end"] = date.End
+	}
+	if len(date.TimeZone) > 0 {
+		j["time_zone"] = date.TimeZone
+	}
+
+	return json.Marshal(j)
+}
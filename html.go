@@ -0,0 +1,352 @@
+package notion
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"html/template"
+	"net/url"
+	"strings"
+)
+
+type HTMLOption struct {
+	//PageURL link of child page and page mention, default is notion.so URL
+	PageURL func(PageID string) string
+	//DatabaseURL link of database mention, default is notion.so URL
+	DatabaseURL func(DatabaseID string) string
+	//ImageURL source of image block, e.g. path of downloaded file. default is URL of image
+	ImageURL func(BlockID, URL string) string
+	//ClassPrefix prefix of CSS classes, default is "notion-"
+	ClassPrefix string
+	//Templates replace rendering of block type, executed with *HTMLBlockData
+	Templates map[string]*template.Template
+}
+
+// HTMLBlockData data of block passed to HTMLOption.Templates
+type HTMLBlockData struct {
+	Block Block
+	ID    string
+	Type  string
+	//Class CSS classes of block
+	Class string
+	//Text rendered rich text of block
+	Text template.HTML
+	//Children rendered children of block
+	Children template.HTML
+	Checked  bool
+}
+
+type htmlRenderer struct {
+	option HTMLOption
+}
+
+func newHTMLRenderer(option *HTMLOption) *htmlRenderer {
+	renderer := &htmlRenderer{}
+	if option != nil {
+		renderer.option = *option
+	}
+	if renderer.option.PageURL == nil {
+		renderer.option.PageURL = NotionURL
+	}
+	if renderer.option.DatabaseURL == nil {
+		renderer.option.DatabaseURL = NotionURL
+	}
+	if renderer.option.ImageURL == nil {
+		renderer.option.ImageURL = func(BlockID, URL string) string { return URL }
+	}
+	if len(renderer.option.ClassPrefix) == 0 {
+		renderer.option.ClassPrefix = "notion-"
+	}
+
+	return renderer
+}
+
+// HTMLPage render title of page and block tree as sanitized HTML fragment
+func HTMLPage(page *Page, nodes []*BlockNode, option *HTMLOption) (string, error) {
+	renderer := newHTMLRenderer(option)
+
+	b := &strings.Builder{}
+	b.WriteString(fmt.Sprintf(`<article class="%s">`, renderer.classAttr("page")))
+	if page != nil {
+		if title := renderer.richText(page.Title()); len(title) > 0 {
+			b.WriteString(fmt.Sprintf(`<h1 class="%s">%s</h1>`, renderer.classAttr("page-title"), title))
+		}
+	}
+
+	body, err := renderer.blocks(nodes)
+	if err != nil {
+		return "", err
+	}
+	b.WriteString(body)
+	b.WriteString("</article>")
+
+	return b.String(), nil
+}
+
+// HTMLBlocks render block tree as sanitized HTML fragment
+func HTMLBlocks(nodes []*BlockNode, option *HTMLOption) (string, error) {
+	return newHTMLRenderer(option).blocks(nodes)
+}
+
+// HTMLRichText render rich text as sanitized inline HTML
+func HTMLRichText(text []RichText, option *HTMLOption) string {
+	return newHTMLRenderer(option).richText(text)
+}
+
+func (renderer *htmlRenderer) class(names ...string) string {
+	list := []string{}
+	for _, name := range names {
+		if len(name) > 0 {
+			list = append(list, renderer.option.ClassPrefix+strings.ReplaceAll(name, "_", "-"))
+		}
+	}
+
+	return strings.Join(list, " ")
+}
+
+// classAttr return classes escaped for attribute, ClassPrefix and types of blocks are not trusted
+func (renderer *htmlRenderer) classAttr(names ...string) string {
+	return html.EscapeString(renderer.class(names...))
+}
+
+// colorClass return class name of color, e.g. "red" and "red-background"
+func colorClass(color string) string {
+	if len(color) == 0 || color == string(ColorDefault) {
+		return ""
+	}
+
+	return strings.ReplaceAll(color, "_", "-")
+}
+
+// safeURL allow only http, https, mailto and relative URL
+func safeURL(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return "#"
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "", "http", "https", "mailto":
+		return u.String()
+	}
+
+	return "#"
+}
+
+func listTag(block Block) string {
+	switch block.Type() {
+	case TypeBlockBulletedListItem:
+		return "ul"
+	case TypeBlockNumberedListItem:
+		return "ol"
+	case TypeBlockTodo:
+		return "ul"
+	}
+
+	return ""
+}
+
+func (renderer *htmlRenderer) blocks(nodes []*BlockNode) (string, error) {
+	b := &strings.Builder{}
+
+	list := ""
+	for i, node := range nodes {
+		tag := listTag(node.Block)
+		if len(list) > 0 && (tag != list || node.Block.Type() != nodes[i-1].Block.Type()) {
+			b.WriteString("</" + list + ">")
+			list = ""
+		}
+		if len(tag) > 0 && len(list) == 0 {
+			b.WriteString(fmt.Sprintf(`<%s class="%s">`, tag, renderer.classAttr(node.Block.Type()+"_list")))
+			list = tag
+		}
+
+		s, err := renderer.block(node)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(s)
+	}
+	if len(list) > 0 {
+		b.WriteString("</" + list + ">")
+	}
+
+	return b.String(), nil
+}
+
+func (renderer *htmlRenderer) block(node *BlockNode) (string, error) {
+	block := node.Block
+
+	data := &HTMLBlockData{
+		Block: block,
+		ID:    block.ID(),
+		Type:  block.Type(),
+		Class: renderer.class("block", block.Type()),
+	}
+
+	if rtb, ok := richTextBlock(block); ok {
+		list, _ := rtb.ListText()
+		data.Text = template.HTML(renderer.richText(list))
+	}
+	if todo, ok := block.(*BlockTodo); ok {
+		data.Checked = todo.IsChecked()
+		if data.Checked {
+			data.Class += " " + renderer.class("checked")
+		}
+	}
+
+	if len(node.Children) > 0 {
+		children, err := renderer.blocks(node.Children)
+		if err != nil {
+			return "", err
+		}
+		data.Children = template.HTML(children)
+	}
+
+	if t, ok := renderer.option.Templates[block.Type()]; ok && t != nil {
+		buf := &bytes.Buffer{}
+		if err := t.Execute(buf, data); err != nil {
+			return "", fmt.Errorf("template of '%s': %v", block.Type(), err)
+		}
+		return buf.String(), nil
+	}
+
+	id := ""
+	if len(data.ID) > 0 {
+		id = fmt.Sprintf(` id="%s"`, html.EscapeString(data.ID))
+	}
+	class := html.EscapeString(data.Class)
+	children := ""
+	if len(data.Children) > 0 {
+		children = fmt.Sprintf(`<div class="%s">%s</div>`, renderer.classAttr("children"), data.Children)
+	}
+
+	//h1 is reserved for title of page, so headings are shifted by one level
+	switch block.Type() {
+	case TypeBlockParagraph:
+		return fmt.Sprintf(`<p%s class="%s">%s</p>%s`, id, class, data.Text, children), nil
+	case TypeBlockHeading1:
+		return fmt.Sprintf(`<h2%s class="%s">%s</h2>`, id, class, data.Text), nil
+	case TypeBlockHeading2:
+		return fmt.Sprintf(`<h3%s class="%s">%s</h3>`, id, class, data.Text), nil
+	case TypeBlockHeading3:
+		return fmt.Sprintf(`<h4%s class="%s">%s</h4>`, id, class, data.Text), nil
+	case TypeBlockBulletedListItem, TypeBlockNumberedListItem:
+		return fmt.Sprintf(`<li%s class="%s">%s%s</li>`, id, class, data.Text, data.Children), nil
+	case TypeBlockTodo:
+		checked := ""
+		if data.Checked {
+			checked = " checked"
+		}
+		return fmt.Sprintf(`<li%s class="%s"><input type="checkbox" disabled%s> %s%s</li>`, id, class, checked, data.Text, data.Children), nil
+	case TypeBlockToggle:
+		return fmt.Sprintf(`<details%s class="%s"><summary>%s</summary>%s</details>`, id, class, data.Text, data.Children), nil
+	case TypeBlockChildPage:
+		title := ""
+		if j, ok := block.Json().GetJSON(TypeBlockChildPage); ok {
+			title = j.GetString("title")
+		}
+		return fmt.Sprintf(`<p%s class="%s"><a href="%s">%s</a></p>`, id, class,
+			html.EscapeString(safeURL(renderer.option.PageURL(block.ID()))), html.EscapeString(title)), nil
+	case TypeBlockImage:
+		image, ok := block.(*BlockImage)
+		if !ok {
+			break
+		}
+		caption := ""
+		if text := image.Caption(); len(text) > 0 {
+			caption = fmt.Sprintf(`<figcaption class="%s">%s</figcaption>`, renderer.classAttr("caption"), renderer.richText(text))
+		}
+		return fmt.Sprintf(`<figure%s class="%s"><img src="%s" alt="%s">%s</figure>`, id, class,
+			html.EscapeString(safeURL(renderer.option.ImageURL(block.ID(), image.URL()))), html.EscapeString(PlainText(image.Caption())), caption), nil
+	}
+
+	return fmt.Sprintf("<!-- %s block -->", html.EscapeString(strings.ReplaceAll(block.Type(), "--", ""))), nil
+}
+
+func (renderer *htmlRenderer) richText(text []RichText) string {
+	b := &strings.Builder{}
+
+	for i := range text {
+		b.WriteString(renderer.run(&text[i]))
+	}
+
+	return b.String()
+}
+
+func (renderer *htmlRenderer) run(rt *RichText) string {
+	annotations := annotationsOf(rt)
+
+	body := ""
+	link := ""
+	classes := []string{}
+
+	switch rt.Type() {
+	case "equation":
+		equation, err := rt.GetEquation()
+		if err != nil {
+			return ""
+		}
+		body = fmt.Sprintf(`<span class="%s">%s</span>`, renderer.classAttr("equation"), html.EscapeString(equation.Expression))
+	case "mention":
+		body = html.EscapeString(rt.PlainText())
+		if mention, err := rt.GetMention(); err == nil {
+			switch mention.Type {
+			case MentionTypePage:
+				link = renderer.option.PageURL(mention.Page.ID())
+			case MentionTypeDatabase:
+				link = renderer.option.DatabaseURL(mention.Database.ID())
+			case MentionTypeLinkPreview:
+				link = mention.LinkPreview.URL
+			}
+			classes = append(classes, "mention", "mention-"+mention.Type)
+		} else {
+			classes = append(classes, "mention")
+		}
+	default:
+		content := rt.PlainText()
+		if text, err := rt.GetText(); err == nil {
+			content = text.Content
+			if text.Link != nil {
+				link = text.Link.URL
+			}
+		}
+		if len(link) == 0 {
+			link = rt.Href()
+		}
+		body = strings.ReplaceAll(html.EscapeString(content), "\n", "<br>")
+	}
+
+	if len(body) == 0 {
+		return ""
+	}
+
+	if annotations.Code {
+		body = fmt.Sprintf(`<code class="%s">%s</code>`, renderer.classAttr("code"), body)
+	}
+	if annotations.Bold {
+		body = fmt.Sprintf(`<strong class="%s">%s</strong>`, renderer.classAttr("bold"), body)
+	}
+	if annotations.Italic {
+		body = fmt.Sprintf(`<em class="%s">%s</em>`, renderer.classAttr("italic"), body)
+	}
+	if annotations.Strikethrough {
+		body = fmt.Sprintf(`<s class="%s">%s</s>`, renderer.classAttr("strikethrough"), body)
+	}
+	if annotations.Underline {
+		body = fmt.Sprintf(`<u class="%s">%s</u>`, renderer.classAttr("underline"), body)
+	}
+	if color := colorClass(annotations.Color); len(color) > 0 {
+		classes = append(classes, color)
+	}
+
+	if len(link) > 0 {
+		return fmt.Sprintf(`<a class="%s" href="%s">%s</a>`, renderer.classAttr(append([]string{"link"}, classes...)...),
+			html.EscapeString(safeURL(link)), body)
+	}
+	if len(classes) > 0 {
+		return fmt.Sprintf(`<span class="%s">%s</span>`, renderer.classAttr(classes...), body)
+	}
+
+	return body
+}
@@ -0,0 +1,654 @@
+package notion
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// DroppedElement element which could not be converted by ParseHTML
+type DroppedElement struct {
+	Tag string
+	//Path ancestors of element, e.g. "body > div > iframe"
+	Path string
+}
+
+var (
+	htmlRawElement = regexp.MustCompile(`(?is)<(script|style|noscript|template)\b[^>]*>.*?</(?:script|style|noscript|template)\s*>`)
+	htmlWhitespace = regexp.MustCompile(`[ \t\r\n\f]+`)
+)
+
+var htmlVoidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true, "input": true,
+	"link": true, "meta": true, "param": true, "source": true, "track": true, "wbr": true,
+}
+
+var htmlBlockElements = map[string]bool{
+	"html": true, "body": true, "div": true, "section": true, "article": true, "main": true, "header": true,
+	"footer": true, "nav": true, "aside": true, "p": true, "h1": true, "h2": true, "h3": true, "h4": true,
+	"h5": true, "h6": true, "ul": true, "ol": true, "li": true, "table": true, "thead": true, "tbody": true,
+	"tfoot": true, "tr": true, "pre": true, "blockquote": true, "details": true, "hr": true, "figure": true,
+	"figcaption": true, "dl": true, "dt": true, "dd": true, "center": true, "address": true, "caption": true,
+}
+
+// htmlDroppedElements elements which content can not be converted
+var htmlDroppedElements = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true, "head": true, "iframe": true,
+	"object": true, "embed": true, "video": true, "audio": true, "canvas": true, "svg": true, "math": true,
+	"form": true, "select": true, "textarea": true, "button": true, "map": true,
+}
+
+type htmlNode struct {
+	tag      string
+	attr     map[string]string
+	text     string
+	parent   *htmlNode
+	children []*htmlNode
+}
+
+func (node *htmlNode) path() string {
+	list := []string{}
+	for n := node; n != nil && len(n.tag) > 0 && n.tag != "#root"; n = n.parent {
+		list = append([]string{n.tag}, list...)
+	}
+
+	return strings.Join(list, " > ")
+}
+
+// parseHTMLTree parse HTML into loose element tree, malformed markup is read as text like browser does
+func parseHTMLTree(Source string) (*htmlNode, []DroppedElement) {
+	dropped := []DroppedElement{}
+	for _, m := range htmlRawElement.FindAllStringSubmatch(Source, -1) {
+		dropped = append(dropped, DroppedElement{Tag: strings.ToLower(m[1]), Path: strings.ToLower(m[1])})
+	}
+	Source = htmlRawElement.ReplaceAllString(Source, "")
+
+	root := &htmlNode{tag: "#root"}
+	current := root
+
+	closeTo := func(tag string, stop ...string) {
+		for n := current; n != nil && n != root; n = n.parent {
+			for _, s := range stop {
+				if n.tag == s {
+					return
+				}
+			}
+			if n.tag == tag {
+				current = n.parent
+				return
+			}
+		}
+	}
+
+	for _, token := range tokenizeHTML(Source) {
+		switch {
+		case len(token.tag) == 0:
+			current.children = append(current.children, &htmlNode{text: token.text, parent: current})
+		case !token.end:
+			tag := token.tag
+
+			//implied end tags
+			if htmlBlockElements[tag] && current.tag == "p" {
+				current = current.parent
+			}
+			switch tag {
+			case "li":
+				closeTo("li", "ul", "ol")
+			case "tr":
+				closeTo("tr", "table")
+			case "td", "th":
+				closeTo("td", "tr", "table")
+				closeTo("th", "tr", "table")
+			}
+
+			node := &htmlNode{tag: tag, attr: token.attr, parent: current}
+			current.children = append(current.children, node)
+
+			if !htmlVoidElements[tag] {
+				current = node
+			}
+		case !htmlVoidElements[token.tag]:
+			closeTo(token.tag)
+		}
+	}
+
+	return root, dropped
+}
+
+type htmlToken struct {
+	//tag name of start or end tag, empty for text
+	tag  string
+	end  bool
+	attr map[string]string
+	text string
+}
+
+// tokenizeHTML split HTML into text and tags like browser does, "<" which does not start tag is text.
+// comments, doctype and processing instructions are skipped
+func tokenizeHTML(Source string) []htmlToken {
+	tokens := []htmlToken{}
+	text := &strings.Builder{}
+
+	flush := func() {
+		if text.Len() > 0 {
+			tokens = append(tokens, htmlToken{text: html.UnescapeString(text.String())})
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(Source); {
+		rest := Source[i:]
+		if rest[0] != '<' {
+			n := strings.IndexByte(rest, '<')
+			if n < 0 {
+				n = len(rest)
+			}
+			text.WriteString(rest[:n])
+			i += n
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			n := strings.Index(rest[4:], "-->")
+			if n < 0 {
+				i = len(Source)
+			} else {
+				i += 4 + n + 3
+			}
+			continue
+		case strings.HasPrefix(rest, "<!"), strings.HasPrefix(rest, "<?"):
+			n := strings.IndexByte(rest, '>')
+			if n < 0 {
+				i = len(Source)
+			} else {
+				i += n + 1
+			}
+			continue
+		}
+
+		token, n := readHTMLTag(rest)
+		if n == 0 {
+			text.WriteByte('<')
+			i++
+			continue
+		}
+		flush()
+		tokens = append(tokens, token)
+		i += n
+	}
+	flush()
+
+	return tokens
+}
+
+func isHTMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+// readHTMLTag read start or end tag at beginning of s and return its length, 0 if s does not start with complete tag
+func readHTMLTag(s string) (htmlToken, int) {
+	token := htmlToken{attr: map[string]string{}}
+
+	i := 1
+	if i < len(s) && s[i] == '/' {
+		token.end = true
+		i++
+	}
+	if i >= len(s) || !('a' <= s[i] && s[i] <= 'z' || 'A' <= s[i] && s[i] <= 'Z') {
+		return htmlToken{}, 0
+	}
+	start := i
+	for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '/' && s[i] != '>' {
+		i++
+	}
+	token.tag = strings.ToLower(s[start:i])
+
+	for {
+		for i < len(s) && (isHTMLSpace(s[i]) || s[i] == '/') {
+			i++
+		}
+		if i >= len(s) {
+			return htmlToken{}, 0
+		}
+		if s[i] == '>' {
+			return token, i + 1
+		}
+
+		start := i
+		i++
+		for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '/' && s[i] != '>' && s[i] != '=' {
+			i++
+		}
+		name := strings.ToLower(s[start:i])
+		for i < len(s) && isHTMLSpace(s[i]) {
+			i++
+		}
+
+		value := ""
+		if i < len(s) && s[i] == '=' {
+			i++
+			for i < len(s) && isHTMLSpace(s[i]) {
+				i++
+			}
+			if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+				n := strings.IndexByte(s[i+1:], s[i])
+				if n < 0 {
+					return htmlToken{}, 0
+				}
+				value = s[i+1 : i+1+n]
+				i += n + 2
+			} else {
+				start := i
+				for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '>' {
+					i++
+				}
+				value = s[start:i]
+			}
+		}
+
+		//first of duplicate attributes wins
+		if _, ok := token.attr[name]; !ok {
+			token.attr[name] = html.UnescapeString(value)
+		}
+	}
+}
+
+type htmlConverter struct {
+	dropped []DroppedElement
+	//images image blocks found in inline content, which are written after the block
+	images []Block
+	//linkImages convert images into links, for rich text only
+	linkImages bool
+}
+
+func (converter *htmlConverter) drop(node *htmlNode) {
+	converter.dropped = append(converter.dropped, DroppedElement{Tag: node.tag, Path: node.path()})
+}
+
+// ParseHTML convert HTML into blocks and report elements which were dropped.
+// block types which are not supported by the API are converted like ParseMarkdown:
+// <pre> becomes paragraph with code annotation, <blockquote> becomes its inner blocks, table becomes paragraph per row
+// and image of http(s) URL becomes image block after its paragraph, others become link. <details> becomes toggle,
+// <figcaption> of single image becomes its caption
+func ParseHTML(Source string) ([]Block, []DroppedElement, error) {
+	root, dropped := parseHTMLTree(Source)
+
+	converter := &htmlConverter{dropped: dropped}
+	blocks := converter.blocks(root.children)
+
+	return blocks, converter.dropped, nil
+}
+
+// ParseHTMLRichText convert inline HTML into rich text and report elements which were dropped
+func ParseHTMLRichText(Source string) ([]RichText, []DroppedElement, error) {
+	root, dropped := parseHTMLTree(Source)
+
+	converter := &htmlConverter{dropped: dropped, linkImages: true}
+	runs := trimRichText(converter.inline(root.children, *NewAnnotations(), "", false, nil))
+
+	return runs, converter.dropped, nil
+}
+
+func isHTMLBlock(node *htmlNode) bool {
+	return htmlBlockElements[node.tag] || htmlDroppedElements[node.tag] || strings.Contains(node.tag, ":")
+}
+
+func (converter *htmlConverter) blocks(nodes []*htmlNode) []Block {
+	blocks := []Block{}
+	inline := []*htmlNode{}
+
+	flush := func() {
+		if len(inline) == 0 {
+			return
+		}
+		text := trimRichText(converter.inline(inline, *NewAnnotations(), "", false, nil))
+		if len(text) > 0 {
+			blocks = append(blocks, NewBlockParagraph(text))
+		}
+		blocks = append(blocks, converter.takeImages()...)
+		inline = []*htmlNode{}
+	}
+
+	for _, node := range nodes {
+		if len(node.tag) == 0 || !isHTMLBlock(node) {
+			inline = append(inline, node)
+			continue
+		}
+
+		flush()
+		blocks = append(blocks, converter.block(node)...)
+		blocks = append(blocks, converter.takeImages()...)
+	}
+	flush()
+
+	return blocks
+}
+
+func (converter *htmlConverter) takeImages() []Block {
+	images := converter.images
+	converter.images = nil
+
+	return images
+}
+
+func (converter *htmlConverter) block(node *htmlNode) []Block {
+	switch node.tag {
+	case "h1":
+		return []Block{NewBlockHeading1(converter.text(node))}
+	case "h2":
+		return []Block{NewBlockHeading2(converter.text(node))}
+	case "h3", "h4", "h5", "h6":
+		return []Block{NewBlockHeading3(converter.text(node))}
+	case "p", "dt", "figcaption", "caption", "address":
+		text := converter.text(node)
+		if len(text) == 0 {
+			return nil
+		}
+		return []Block{NewBlockParagraph(text)}
+	case "pre":
+		code := &strings.Builder{}
+		converter.rawText(node, code)
+		annotations := NewAnnotations()
+		annotations.Code = true
+		content := strings.TrimSuffix(strings.TrimPrefix(code.String(), "\n"), "\n")
+		if len(content) == 0 {
+			return nil
+		}
+		return []Block{NewBlockParagraph([]RichText{newRichTextRun(content, *annotations, "")})}
+	case "ul", "ol":
+		blocks := []Block{}
+		for _, child := range node.children {
+			if child.tag == "li" {
+				blocks = append(blocks, converter.listItem(child, node.tag == "ol"))
+			} else if len(child.tag) > 0 {
+				blocks = append(blocks, converter.blocks([]*htmlNode{child})...)
+			}
+		}
+		return blocks
+	case "li":
+		return []Block{converter.listItem(node, false)}
+	case "table":
+		return converter.table(node)
+	case "hr":
+		//divider is not supported by the API
+		converter.drop(node)
+		return nil
+	case "figure":
+		//figcaption of single image is its caption
+		caption := []RichText{}
+		rest := []*htmlNode{}
+		for _, child := range node.children {
+			if child.tag == "figcaption" && len(caption) == 0 {
+				caption = converter.text(child)
+				continue
+			}
+			rest = append(rest, child)
+		}
+
+		blocks := converter.blocks(rest)
+		images := []Block{}
+		for _, block := range blocks {
+			if block.Type() == TypeBlockImage {
+				images = append(images, block)
+			}
+		}
+		switch {
+		case len(caption) == 0:
+		case len(images) == 1:
+			list := []JSON{}
+			for _, rt := range caption {
+				list = append(list, rt.JSON)
+			}
+			image, _ := images[0].Json().GetJSON(TypeBlockImage)
+			image["caption"] = list
+		default:
+			blocks = append(blocks, NewBlockParagraph(caption))
+		}
+		return blocks
+	case "details":
+		summary := []RichText{}
+		rest := []*htmlNode{}
+		for _, child := range node.children {
+			if child.tag == "summary" && len(summary) == 0 {
+				summary = converter.text(child)
+				continue
+			}
+			rest = append(rest, child)
+		}
+		return []Block{NewBlockToggle(summary, converter.blocks(rest)...)}
+	}
+
+	if htmlDroppedElements[node.tag] || strings.Contains(node.tag, ":") {
+		converter.drop(node)
+		return nil
+	}
+
+	//containers like div, blockquote and section
+	return converter.blocks(node.children)
+}
+
+func (converter *htmlConverter) listItem(node *htmlNode, ordered bool) Block {
+	inline := []*htmlNode{}
+	rest := []*htmlNode{}
+	task, checked := false, false
+
+	for _, child := range node.children {
+		if len(rest) == 0 && (len(child.tag) == 0 || !isHTMLBlock(child)) {
+			if child.tag == "input" && strings.EqualFold(child.attr["type"], "checkbox") && !task {
+				task = true
+				_, checked = child.attr["checked"]
+				continue
+			}
+			inline = append(inline, child)
+			continue
+		}
+		rest = append(rest, child)
+	}
+
+	text := trimRichText(converter.inline(inline, *NewAnnotations(), "", false, nil))
+	//<li><p>text</p>...</li>
+	if len(text) == 0 && len(rest) > 0 && rest[0].tag == "p" {
+		text = converter.text(rest[0])
+		rest = rest[1:]
+	}
+	children := append(converter.takeImages(), converter.blocks(rest)...)
+
+	switch {
+	case task:
+		return NewBlockTodo(checked, text, children...)
+	case ordered:
+		return NewBlockNumberedListItem(text, children...)
+	}
+
+	return NewBlockBulletedListItem(text, children...)
+}
+
+func (converter *htmlConverter) table(node *htmlNode) []Block {
+	blocks := []Block{}
+
+	var rows func(n *htmlNode)
+	rows = func(n *htmlNode) {
+		for _, child := range n.children {
+			switch child.tag {
+			case "thead", "tbody", "tfoot":
+				rows(child)
+			case "caption":
+				if text := converter.text(child); len(text) > 0 {
+					blocks = append(blocks, NewBlockParagraph(text))
+				}
+			case "tr":
+				text := []RichText{}
+				for _, cell := range child.children {
+					if cell.tag != "td" && cell.tag != "th" {
+						continue
+					}
+
+					annotations := NewAnnotations()
+					annotations.Bold = cell.tag == "th"
+					if len(text) > 0 {
+						text = appendRichTextRun(text, " | ", *NewAnnotations(), "")
+					}
+					text = append(text, trimRichText(converter.inline(cell.children, *annotations, "", false, nil))...)
+				}
+				if len(text) > 0 {
+					blocks = append(blocks, NewBlockParagraph(text))
+				}
+			}
+		}
+	}
+	rows(node)
+
+	return blocks
+}
+
+func (converter *htmlConverter) text(node *htmlNode) []RichText {
+	return trimRichText(converter.inline(node.children, *NewAnnotations(), "", false, nil))
+}
+
+func (converter *htmlConverter) rawText(node *htmlNode, b *strings.Builder) {
+	for _, child := range node.children {
+		if len(child.tag) == 0 {
+			b.WriteString(child.text)
+		} else if child.tag == "br" {
+			b.WriteString("\n")
+		} else {
+			converter.rawText(child, b)
+		}
+	}
+}
+
+func (converter *htmlConverter) inline(nodes []*htmlNode, annotations Annotations, link string, pre bool, runs []RichText) []RichText {
+	if runs == nil {
+		runs = []RichText{}
+	}
+
+	for _, node := range nodes {
+		if len(node.tag) == 0 {
+			content := node.text
+			if !pre {
+				content = htmlWhitespace.ReplaceAllString(content, " ")
+				//collapse whitespace across runs
+				if n := len(runs); strings.HasPrefix(content, " ") && n > 0 && strings.HasSuffix(runs[n-1].PlainText(), " ") {
+					content = content[1:]
+				}
+			}
+			if len(content) > 0 {
+				runs = appendRichTextRun(runs, content, annotations, link)
+			}
+			continue
+		}
+
+		a := annotations
+		l := link
+		p := pre
+
+		switch node.tag {
+		case "br":
+			runs = appendRichTextRun(runs, "\n", annotations, link)
+			continue
+		case "img":
+			src := strings.TrimSpace(node.attr["src"])
+			if len(src) == 0 {
+				converter.drop(node)
+				continue
+			}
+			alt := strings.TrimSpace(node.attr["alt"])
+			if !converter.linkImages && (strings.HasPrefix(src, "https://") || strings.HasPrefix(src, "http://")) {
+				converter.images = append(converter.images, newHTMLImage(src, alt))
+				continue
+			}
+			if len(alt) == 0 {
+				alt = src
+			}
+			runs = appendRichTextRun(runs, alt, annotations, src)
+			continue
+		case "input":
+			converter.drop(node)
+			continue
+		case "b", "strong":
+			a.Bold = true
+		case "i", "em", "cite", "var", "dfn":
+			a.Italic = true
+		case "s", "del", "strike":
+			a.Strikethrough = true
+		case "u", "ins":
+			a.Underline = true
+		case "code", "kbd", "samp", "tt":
+			a.Code = true
+		case "pre":
+			a.Code = true
+			p = true
+		case "a":
+			if href := strings.TrimSpace(node.attr["href"]); len(href) > 0 && !strings.HasPrefix(href, "#") {
+				l = safeURL(href)
+				if l == "#" {
+					l = link
+				}
+			}
+		default:
+			if htmlDroppedElements[node.tag] || strings.Contains(node.tag, ":") {
+				converter.drop(node)
+				continue
+			}
+		}
+
+		if htmlBlockElements[node.tag] && len(runs) > 0 && !strings.HasSuffix(runs[len(runs)-1].PlainText(), "\n") {
+			runs = appendRichTextRun(runs, "\n", annotations, link)
+		}
+		runs = converter.inline(node.children, a, l, p, runs)
+	}
+
+	return runs
+}
+
+// newHTMLImage image block of external URL with alternative text as caption
+func newHTMLImage(URL, alt string) Block {
+	block := NewBlockImage(URL)
+	if len(alt) > 0 {
+		image, _ := block.Json().GetJSON(TypeBlockImage)
+		image["caption"] = []JSON{NewRichText(alt).JSON}
+	}
+
+	return block
+}
+
+// trimRichText remove leading and trailing whitespace of text
+func trimRichText(text []RichText) []RichText {
+	for len(text) > 0 {
+		first, err := text[0].GetText()
+		if err != nil {
+			break
+		}
+		content := strings.TrimLeft(first.Content, " \n")
+		if len(content) > 0 {
+			text[0] = newRichTextRun(content, *annotationsOf(&text[0]), linkOf(first))
+			break
+		}
+		text = text[1:]
+	}
+
+	for len(text) > 0 {
+		n := len(text) - 1
+		last, err := text[n].GetText()
+		if err != nil {
+			break
+		}
+		content := strings.TrimRight(last.Content, " \n")
+		if len(content) > 0 {
+			text[n] = newRichTextRun(content, *annotationsOf(&text[n]), linkOf(last))
+			break
+		}
+		text = text[:n]
+	}
+
+	return text
+}
+
+func linkOf(text *Text) string {
+	if text.Link == nil {
+		return ""
+	}
+
+	return text.Link.URL
+}
@@ -0,0 +1,68 @@
+package notion
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseHTML(t *testing.T) {
+	tests := []struct {
+		source string
+		//markdown rendered from parsed blocks
+		markdown string
+		dropped  int
+		//lossy rendered HTML is parsed into other blocks, e.g. headings are shifted by one level
+		lossy bool
+	}{
+		{source: "<p>Plain paragraph</p>", markdown: "Plain paragraph"},
+		{source: "<h1>Title</h1><h2>Section</h2>", markdown: "# Title\n\n## Section", lossy: true},
+		{source: "<p><b>bold</b> <em>italic</em> <code>code</code> <a href=\"https://example.com\">link</a></p>", markdown: "**bold** _italic_ `code` [link](https://example.com)"},
+		{source: "<ul><li>one</li><li>two<ul><li>nested</li></ul></li></ul>", markdown: "- one\n- two\n  - nested"},
+		{source: "<ol><li>first</li><li>second</li></ol>", markdown: "1. first\n2. second"},
+		{source: "<p>a < b and c > d</p>", markdown: "a \\< b and c \\> d"},
+		{source: "<p>t&eacute;st &lt;3</p>", markdown: "tést \\<3"},
+		{source: "<P>A<BR/>B</P>", markdown: "A\\\nB"},
+		{source: "<p>unterminated <b", markdown: "unterminated \\<b"},
+		{source: "<p>before</p><hr><p>after</p>", markdown: "before\n\nafter", dropped: 1},
+		{source: "<p>x<img src=\"https://example.com/a.png\" alt=\"Cap &amp; tion\"></p>", markdown: "x\n\n![Cap & tion](https://example.com/a.png)"},
+		{source: "<ul><li>item<img src=https://example.com/b.png></li></ul>", markdown: "- item\n\n  ![](https://example.com/b.png)"},
+		{source: "<figure><img src=\"https://example.com/c.png\"><figcaption>Caption</figcaption></figure>", markdown: "![Caption](https://example.com/c.png)"},
+		{source: "<!DOCTYPE html><html><body><!-- comment --><p class='a' data-x=\"1>2\">text</p></body></html>", markdown: "text"},
+		{source: "<p>x</p><script>alert(1)</script>", markdown: "x", dropped: 1},
+	}
+
+	for _, test := range tests {
+		blocks, dropped, err := ParseHTML(test.source)
+		if err != nil {
+			t.Errorf("%q: %v", test.source, err)
+			continue
+		}
+
+		got := strings.TrimSpace(MarkdownBlocks(markdownNodes(blocks, 1), nil))
+		if got != test.markdown {
+			t.Errorf("%q: got %q, want %q", test.source, got, test.markdown)
+		}
+		if len(dropped) != test.dropped {
+			t.Errorf("%q: dropped %v", test.source, dropped)
+		}
+
+		if test.lossy {
+			continue
+		}
+
+		// HTML rendered from blocks is parsed into same blocks
+		rendered, err := HTMLBlocks(markdownNodes(blocks, 1), nil)
+		if err != nil {
+			t.Errorf("%q: %v", test.source, err)
+			continue
+		}
+		again, _, err := ParseHTML(rendered)
+		if err != nil {
+			t.Errorf("%q: %v", rendered, err)
+			continue
+		}
+		if got := strings.TrimSpace(MarkdownBlocks(markdownNodes(again, 1), nil)); got != test.markdown {
+			t.Errorf("%q: round trip of %q got %q", test.source, rendered, got)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package notion
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -42,13 +43,23 @@ func (j JSON) Get(name string) interface{} {
 }
 
 func (j JSON) GetString(name string) string {
-	return fmt.Sprint(j.Get(name))
+	v := j.Get(name)
+	if v == nil {
+		return ""
+	}
+
+	return fmt.Sprint(v)
 }
 
 func (j JSON) GetInt(name string) int {
-	i, _ := strconv.Atoi(j.GetString(name))
+	s := j.GetString(name)
+	if i, err := strconv.Atoi(s); err == nil {
+		return i
+	}
 
-	return i
+	f, _ := strconv.ParseFloat(s, 64)
+
+	return int(f)
 }
 
 func (j JSON) GetBool(name string) bool {
@@ -63,6 +74,26 @@ func (j JSON) GetFloat(name string) float64 {
 	return f
 }
 
+// GetNumber return number as it is written in JSON without loss of precision, empty if value is not number
+func (j JSON) GetNumber(name string) json.Number {
+	switch v := j.Get(name).(type) {
+	case json.Number:
+		return v
+	case float64:
+		return json.Number(strconv.FormatFloat(v, 'f', -1, 64))
+	case float32:
+		return json.Number(strconv.FormatFloat(float64(v), 'f', -1, 32))
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return json.Number(fmt.Sprint(v))
+	case string:
+		if numberPattern.MatchString(v) {
+			return json.Number(v)
+		}
+	}
+
+	return ""
+}
+
 func (j JSON) GetJSON(name string) (JSON, bool) {
 	if v := j.Get(name); v != nil {
 		if m, ok := v.(JSON); ok {
@@ -106,7 +137,7 @@ func (j JSON) Marshal(v interface{}) error {
 		return err
 	}
 
-	return json.Unmarshal(b, &j)
+	return decodeJSON(b, &j)
 }
 
 func (j JSON) Unmarshal(v interface{}) error {
@@ -115,5 +146,13 @@ func (j JSON) Unmarshal(v interface{}) error {
 		return err
 	}
 
-	return json.Unmarshal(b, v)
+	return decodeJSON(b, v)
+}
+
+// decodeJSON decode numbers as json.Number, so large integers and decimals are kept exactly
+func decodeJSON(b []byte, v interface{}) error {
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+
+	return d.Decode(v)
 }
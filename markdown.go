@@ -0,0 +1,382 @@
+package notion
+
+import (
+	"fmt"
+	"strings"
+)
+
+type MarkdownOption struct {
+	//PageURL link of child page and page mention, default is notion.so URL
+	PageURL func(PageID string) string
+	//DatabaseURL link of database mention, default is notion.so URL
+	DatabaseURL func(DatabaseID string) string
+	//ImageURL source of image block, e.g. path of downloaded file. default is URL of image
+	ImageURL func(BlockID, URL string) string
+}
+
+// NotionURL return notion.so URL of page or database
+func NotionURL(ID string) string {
+	return "https://www.notion.so/" + strings.ReplaceAll(ID, "-", "")
+}
+
+type markdownRenderer struct {
+	option MarkdownOption
+}
+
+func newMarkdownRenderer(option *MarkdownOption) *markdownRenderer {
+	renderer := &markdownRenderer{}
+	if option != nil {
+		renderer.option = *option
+	}
+	if renderer.option.PageURL == nil {
+		renderer.option.PageURL = NotionURL
+	}
+	if renderer.option.DatabaseURL == nil {
+		renderer.option.DatabaseURL = NotionURL
+	}
+	if renderer.option.ImageURL == nil {
+		renderer.option.ImageURL = func(BlockID, URL string) string { return URL }
+	}
+
+	return renderer
+}
+
+// MarkdownPage render title of page and block tree as GFM document
+func MarkdownPage(page *Page, nodes []*BlockNode, option *MarkdownOption) string {
+	renderer := newMarkdownRenderer(option)
+
+	b := &strings.Builder{}
+	if page != nil {
+		if title := renderer.richText(page.Title()); len(title) > 0 {
+			b.WriteString("# " + title + "\n\n")
+		}
+	}
+
+	if body := renderer.blocks(nodes, ""); len(body) > 0 {
+		b.WriteString(body + "\n")
+	}
+
+	return b.String()
+}
+
+// MarkdownBlocks render block tree as GFM
+func MarkdownBlocks(nodes []*BlockNode, option *MarkdownOption) string {
+	return newMarkdownRenderer(option).blocks(nodes, "")
+}
+
+// MarkdownRichText render rich text as inline GFM with annotations, links and mentions
+func MarkdownRichText(text []RichText, option *MarkdownOption) string {
+	return newMarkdownRenderer(option).richText(text)
+}
+
+// sameList check consecutive list items are rendered in one list
+func sameList(a, b Block) bool {
+	if !isListBlock(a) || !isListBlock(b) {
+		return false
+	}
+
+	return (a.Type() == TypeBlockNumberedListItem) == (b.Type() == TypeBlockNumberedListItem)
+}
+
+func isListBlock(block Block) bool {
+	switch block.Type() {
+	case TypeBlockBulletedListItem, TypeBlockNumberedListItem, TypeBlockTodo:
+		return true
+	}
+
+	return false
+}
+
+func (renderer *markdownRenderer) blocks(nodes []*BlockNode, indent string) string {
+	b := &strings.Builder{}
+
+	number := 0
+	for i, node := range nodes {
+		if i > 0 {
+			if sameList(nodes[i-1].Block, node.Block) {
+				b.WriteString("\n")
+			} else {
+				b.WriteString("\n\n")
+			}
+		}
+
+		if node.Block.Type() == TypeBlockNumberedListItem {
+			number++
+		} else {
+			number = 0
+		}
+
+		b.WriteString(renderer.block(node, indent, number))
+	}
+
+	return b.String()
+}
+
+func (renderer *markdownRenderer) block(node *BlockNode, indent string, number int) string {
+	block := node.Block
+
+	text := ""
+	if rtb, ok := richTextBlock(block); ok {
+		list, _ := rtb.ListText()
+		if block.Type() == TypeBlockParagraph && isCodeText(list) {
+			return codeBlock(list, indent)
+		}
+		text = renderer.richText(list)
+	}
+
+	marker := ""
+	switch block.Type() {
+	case TypeBlockParagraph:
+	case TypeBlockHeading1:
+		marker = "# "
+	case TypeBlockHeading2:
+		marker = "## "
+	case TypeBlockHeading3:
+		marker = "### "
+	case TypeBlockBulletedListItem:
+		marker = "- "
+	case TypeBlockNumberedListItem:
+		marker = fmt.Sprintf("%d. ", number)
+	case TypeBlockTodo:
+		marker = "- [ ] "
+		if todo, ok := block.(*BlockTodo); ok && todo.IsChecked() {
+			marker = "- [x] "
+		}
+	case TypeBlockToggle:
+		b := &strings.Builder{}
+		b.WriteString(indent + "<details>\n")
+		b.WriteString(indent + "<summary>" + strings.ReplaceAll(text, "\n", " ") + "</summary>\n")
+		if len(node.Children) > 0 {
+			b.WriteString("\n" + renderer.blocks(node.Children, indent) + "\n")
+		}
+		b.WriteString("\n" + indent + "</details>")
+		return b.String()
+	case TypeBlockChildPage:
+		title := ""
+		if j, ok := block.Json().GetJSON(TypeBlockChildPage); ok {
+			title = j.GetString("title")
+		}
+		return indent + fmt.Sprintf("[%s](%s)", escapeMarkdown(title), renderer.option.PageURL(block.ID()))
+	case TypeBlockImage:
+		image, ok := block.(*BlockImage)
+		if !ok {
+			return indent + fmt.Sprintf("<!-- %s block %s -->", block.Type(), block.ID())
+		}
+		caption := PlainText(image.Caption())
+		return indent + fmt.Sprintf("![%s](%s)", escapeMarkdown(caption), renderer.option.ImageURL(block.ID(), image.URL()))
+	default:
+		return indent + fmt.Sprintf("<!-- %s block %s -->", block.Type(), block.ID())
+	}
+
+	pad := strings.Repeat(" ", len(marker))
+	if !isListBlock(block) {
+		pad = ""
+	}
+
+	lines := strings.Split(text, "\n")
+	b := &strings.Builder{}
+	for i, line := range lines {
+		if i == 0 {
+			b.WriteString(indent + marker + line)
+		} else {
+			b.WriteString("\\\n" + indent + pad + line)
+		}
+	}
+
+	if len(node.Children) > 0 {
+		if isListBlock(block) {
+			//paragraphs in list item must be separated by blank line
+			separator := "\n"
+			if !isListBlock(node.Children[0].Block) {
+				separator = "\n\n"
+			}
+			b.WriteString(separator + renderer.blocks(node.Children, indent+pad))
+		} else {
+			b.WriteString("\n\n" + renderer.blocks(node.Children, indent))
+		}
+	}
+
+	return b.String()
+}
+
+func richTextBlock(block Block) (*RichTextBlock, bool) {
+	switch v := block.Interface().(type) {
+	case *BlockParagraph:
+		return v.RichTextBlock, true
+	case *BlockHeading1:
+		return v.RichTextBlock, true
+	case *BlockHeading2:
+		return v.RichTextBlock, true
+	case *BlockHeading3:
+		return v.RichTextBlock, true
+	case *BlockBulletedListItem:
+		return v.RichTextBlock, true
+	case *BlockNumberedListItem:
+		return v.RichTextBlock, true
+	case *BlockTodo:
+		return v.RichTextBlock, true
+	case *BlockToggle:
+		return v.RichTextBlock, true
+	case *RichTextBlock:
+		return v, true
+	case *ChildrenBlock:
+		return v.RichTextBlock, true
+	}
+
+	return nil, false
+}
+
+func annotationsOf(rt *RichText) *Annotations {
+	annotations, err := rt.GetAnnotations()
+	if err != nil {
+		return NewAnnotations()
+	}
+
+	return annotations
+}
+
+func (renderer *markdownRenderer) richText(text []RichText) string {
+	b := &strings.Builder{}
+
+	for i := range text {
+		b.WriteString(renderer.run(&text[i]))
+	}
+
+	return b.String()
+}
+
+func (renderer *markdownRenderer) run(rt *RichText) string {
+	annotations := annotationsOf(rt)
+
+	content := ""
+	link := ""
+
+	switch rt.Type() {
+	case "equation":
+		equation, err := rt.GetEquation()
+		if err != nil {
+			return ""
+		}
+		return "$" + equation.Expression + "$"
+	case "mention":
+		content = rt.PlainText()
+		if mention, err := rt.GetMention(); err == nil {
+			switch mention.Type {
+			case MentionTypePage:
+				link = renderer.option.PageURL(mention.Page.ID())
+			case MentionTypeDatabase:
+				link = renderer.option.DatabaseURL(mention.Database.ID())
+			case MentionTypeLinkPreview:
+				link = mention.LinkPreview.URL
+			}
+		}
+	default:
+		content = rt.PlainText()
+		if text, err := rt.GetText(); err == nil {
+			content = text.Content
+			if text.Link != nil {
+				link = text.Link.URL
+			}
+		}
+		if len(link) == 0 {
+			link = rt.Href()
+		}
+	}
+
+	if len(content) == 0 {
+		return ""
+	}
+
+	//emphasis must not start or end with whitespace, so keep surrounding spaces outside of markers
+	body := strings.TrimSpace(content)
+	if len(body) == 0 {
+		return content
+	}
+	leading := content[:strings.Index(content, body)]
+	trailing := content[len(leading)+len(body):]
+
+	if annotations.Code {
+		body = codeSpan(body)
+	} else {
+		body = escapeMarkdown(body)
+	}
+	if annotations.Bold {
+		body = "**" + body + "**"
+	}
+	if annotations.Italic {
+		body = "_" + body + "_"
+	}
+	if annotations.Strikethrough {
+		body = "~~" + body + "~~"
+	}
+	if annotations.Underline {
+		body = "<u>" + body + "</u>"
+	}
+	if len(link) > 0 {
+		body = "[" + body + "](" + strings.ReplaceAll(link, " ", "%20") + ")"
+	}
+
+	return leading + body + trailing
+}
+
+// isCodeText check text is multiline code, paragraph of it is rendered as fenced code block
+func isCodeText(text []RichText) bool {
+	if len(text) == 0 {
+		return false
+	}
+
+	multiline := false
+	for i := range text {
+		if text[i].Type() != "text" || !annotationsOf(&text[i]).Code {
+			return false
+		}
+		if strings.Contains(text[i].PlainText(), "\n") {
+			multiline = true
+		}
+	}
+
+	return multiline
+}
+
+func codeBlock(text []RichText, indent string) string {
+	code := &strings.Builder{}
+	for i := range text {
+		code.WriteString(text[i].PlainText())
+	}
+
+	fence := "```"
+	for strings.Contains(code.String(), fence) {
+		fence += "`"
+	}
+
+	b := &strings.Builder{}
+	b.WriteString(indent + fence + "\n")
+	for _, line := range strings.Split(code.String(), "\n") {
+		b.WriteString(indent + line + "\n")
+	}
+	b.WriteString(indent + fence)
+
+	return b.String()
+}
+
+func codeSpan(s string) string {
+	fence := "`"
+	for strings.Contains(s, fence) {
+		fence += "`"
+	}
+
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		return fence + " " + s + " " + fence
+	}
+
+	return fence + s + fence
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`,
+	`<`, `\<`, `>`, `\>`, `~`, `\~`, `#`, `\#`, `|`, `\|`, `$`, `\$`,
+)
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
@@ -0,0 +1,662 @@
+package notion
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	markdownHeading   = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	markdownFence     = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})[ \t]*([^`]*)$")
+	markdownListItem  = regexp.MustCompile(`^( *)([-*+]|\d{1,9}[.)])( +|$)(.*)$`)
+	markdownTask      = regexp.MustCompile(`^\[([ xX])\](?: +|$)(.*)$`)
+	markdownBreak     = regexp.MustCompile(`^ {0,3}([-*_])(?:[ \t]*[-*_]){2,}[ \t]*$`)
+	markdownTableRule = regexp.MustCompile(`^ *\|? *:?-+:? *(\| *:?-+:? *)*\|? *$`)
+	markdownComment   = regexp.MustCompile(`^ *<!--.*-->\s*$`)
+)
+
+// ParseMarkdown convert GFM document into blocks.
+// block types which are not supported by the API are converted to the closest supported blocks:
+// fenced code becomes paragraph with code annotation, quote becomes its inner blocks, table becomes paragraph per row
+// and image in text becomes link. image of http(s) URL alone in paragraph becomes image block of external URL.
+// <details> written by MarkdownBlocks is converted back to toggle
+func ParseMarkdown(Source string) []Block {
+	source := strings.ReplaceAll(Source, "\r\n", "\n")
+	source = strings.ReplaceAll(source, "\t", "    ")
+
+	return parseMarkdownBlocks(strings.Split(source, "\n"))
+}
+
+func isBlankLine(line string) bool {
+	return len(strings.TrimSpace(line)) == 0
+}
+
+func lineIndent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// startsMarkdownBlock check line interrupts paragraph
+func startsMarkdownBlock(line string) bool {
+	trimmed := strings.TrimSpace(line)
+
+	return markdownHeading.MatchString(line) ||
+		markdownFence.MatchString(line) ||
+		markdownBreak.MatchString(line) ||
+		markdownListItem.MatchString(line) ||
+		strings.HasPrefix(trimmed, ">") ||
+		trimmed == "<details>"
+}
+
+func parseMarkdownBlocks(lines []string) []Block {
+	blocks := []Block{}
+	paragraph := []string{}
+
+	flush := func() {
+		if len(paragraph) > 0 {
+			if image, ok := parseMarkdownImage(paragraph); ok {
+				blocks = append(blocks, image)
+			} else {
+				blocks = append(blocks, NewBlockParagraph(parseMarkdownInline(joinMarkdownLines(paragraph))))
+			}
+			paragraph = []string{}
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case isBlankLine(line):
+			flush()
+		case markdownComment.MatchString(line):
+			flush()
+		case markdownFence.MatchString(line):
+			flush()
+			match := markdownFence.FindStringSubmatch(line)
+			fence := match[1]
+			indent := lineIndent(line)
+
+			code := []string{}
+			for i++; i < len(lines); i++ {
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), fence) && strings.Trim(strings.TrimSpace(lines[i]), fence[:1]) == "" {
+					break
+				}
+				l := lines[i]
+				if n := lineIndent(l); n > indent {
+					l = l[indent:]
+				} else {
+					l = l[n:]
+				}
+				code = append(code, l)
+			}
+
+			annotations := NewAnnotations()
+			annotations.Code = true
+			blocks = append(blocks, NewBlockParagraph([]RichText{newRichTextRun(strings.Join(code, "\n"), *annotations, "")}))
+		case markdownHeading.MatchString(line):
+			flush()
+			match := markdownHeading.FindStringSubmatch(line)
+			text := parseMarkdownInline(match[2])
+
+			switch len(match[1]) {
+			case 1:
+				blocks = append(blocks, NewBlockHeading1(text))
+			case 2:
+				blocks = append(blocks, NewBlockHeading2(text))
+			default:
+				blocks = append(blocks, NewBlockHeading3(text))
+			}
+		case markdownBreak.MatchString(line):
+			//divider is not supported
+			flush()
+		case strings.HasPrefix(trimmed, ">"):
+			flush()
+			quote := []string{}
+			for ; i < len(lines); i++ {
+				t := strings.TrimSpace(lines[i])
+				if !strings.HasPrefix(t, ">") {
+					if isBlankLine(lines[i]) || startsMarkdownBlock(lines[i]) {
+						break
+					}
+					//lazy continuation
+					quote = append(quote, t)
+					continue
+				}
+				t = strings.TrimPrefix(t, ">")
+				t = strings.TrimPrefix(t, " ")
+				quote = append(quote, t)
+			}
+			i--
+			blocks = append(blocks, parseMarkdownBlocks(quote)...)
+		case trimmed == "<details>":
+			flush()
+			depth := 1
+			summary := ""
+			inner := []string{}
+			for i++; i < len(lines); i++ {
+				t := strings.TrimSpace(lines[i])
+				if t == "<details>" {
+					depth++
+				} else if t == "</details>" {
+					depth--
+					if depth == 0 {
+						break
+					}
+				}
+
+				if depth == 1 && len(summary) == 0 && strings.HasPrefix(t, "<summary>") && strings.HasSuffix(t, "</summary>") {
+					summary = strings.TrimSuffix(strings.TrimPrefix(t, "<summary>"), "</summary>")
+					continue
+				}
+				inner = append(inner, lines[i])
+			}
+			blocks = append(blocks, NewBlockToggle(parseMarkdownInline(summary), parseMarkdownBlocks(unindentLines(inner))...))
+		case markdownListItem.MatchString(line):
+			flush()
+			var block Block
+			block, i = parseMarkdownListItem(lines, i)
+			blocks = append(blocks, block)
+		case len(paragraph) == 0 && i+1 < len(lines) && strings.Contains(line, "|") && markdownTableRule.MatchString(lines[i+1]):
+			flush()
+			header := splitMarkdownTableRow(line)
+			blocks = append(blocks, markdownTableRow(header, true))
+			for i += 2; i < len(lines); i++ {
+				if isBlankLine(lines[i]) || !strings.Contains(lines[i], "|") {
+					break
+				}
+				blocks = append(blocks, markdownTableRow(splitMarkdownTableRow(lines[i]), false))
+			}
+			i--
+		default:
+			if len(paragraph) > 0 && startsMarkdownBlock(line) {
+				flush()
+				i--
+				continue
+			}
+			paragraph = append(paragraph, line)
+		}
+	}
+	flush()
+
+	return blocks
+}
+
+// parseMarkdownListItem parse list item starting at lines[start], return block and index of its last line
+func parseMarkdownListItem(lines []string, start int) (Block, int) {
+	match := markdownListItem.FindStringSubmatch(lines[start])
+	marker := match[2]
+	content := len(match[1]) + len(marker) + len(match[3])
+	if len(match[3]) == 0 {
+		content++
+	}
+
+	body := []string{match[4]}
+	end := start
+	blank := false
+	for i := start + 1; i < len(lines); i++ {
+		line := lines[i]
+		if isBlankLine(line) {
+			blank = true
+			body = append(body, "")
+			continue
+		}
+
+		if lineIndent(line) >= content {
+			body = append(body, line[content:])
+			end = i
+			blank = false
+			continue
+		}
+
+		//lazy continuation of paragraph
+		if !blank && !startsMarkdownBlock(line) {
+			body = append(body, strings.TrimSpace(line))
+			end = i
+			continue
+		}
+
+		break
+	}
+	//trailing blank lines are not part of item
+	body = body[:end-start+1]
+
+	//first paragraph is text of item, the rest is children
+	text := []string{}
+	rest := 0
+	for rest < len(body) && !isBlankLine(body[rest]) && (rest == 0 || !startsMarkdownBlock(body[rest])) {
+		text = append(text, body[rest])
+		rest++
+	}
+	children := parseMarkdownBlocks(body[rest:])
+
+	checked, task := false, false
+	if len(text) > 0 && !strings.ContainsAny(marker, ".)") {
+		if m := markdownTask.FindStringSubmatch(text[0]); m != nil {
+			task = true
+			checked = m[1] != " "
+			text[0] = m[2]
+		}
+	}
+
+	rt := parseMarkdownInline(joinMarkdownLines(text))
+
+	switch {
+	case task:
+		return NewBlockTodo(checked, rt, children...), end
+	case strings.ContainsAny(marker, ".)"):
+		return NewBlockNumberedListItem(rt, children...), end
+	}
+
+	return NewBlockBulletedListItem(rt, children...), end
+}
+
+func unindentLines(lines []string) []string {
+	indent := -1
+	for _, line := range lines {
+		if isBlankLine(line) {
+			continue
+		}
+		if n := lineIndent(line); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+
+	if indent <= 0 {
+		return lines
+	}
+
+	list := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if len(line) >= indent {
+			line = line[indent:]
+		} else {
+			line = strings.TrimLeft(line, " ")
+		}
+		list = append(list, line)
+	}
+
+	return list
+}
+
+// joinMarkdownLines join lines of paragraph, soft breaks become spaces and hard breaks become new lines
+func joinMarkdownLines(lines []string) string {
+	b := &strings.Builder{}
+
+	for i, line := range lines {
+		line = strings.TrimLeft(line, " ")
+		last := i == len(lines)-1
+
+		switch {
+		case !last && strings.HasSuffix(line, "\\"):
+			b.WriteString(strings.TrimSuffix(line, "\\") + "\n")
+		case !last && strings.HasSuffix(line, "  "):
+			b.WriteString(strings.TrimRight(line, " ") + "\n")
+		case !last:
+			b.WriteString(strings.TrimRight(line, " ") + " ")
+		default:
+			b.WriteString(strings.TrimRight(line, " "))
+		}
+	}
+
+	return b.String()
+}
+
+func splitMarkdownTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, "\\|") {
+		line = strings.TrimSuffix(line, "|")
+	}
+
+	cells := []string{}
+	cell := &strings.Builder{}
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' && i+1 < len(line) && line[i+1] == '|' {
+			cell.WriteByte('|')
+			i++
+			continue
+		}
+		if line[i] == '|' {
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+			continue
+		}
+		cell.WriteByte(line[i])
+	}
+	cells = append(cells, strings.TrimSpace(cell.String()))
+
+	return cells
+}
+
+func markdownTableRow(cells []string, header bool) Block {
+	text := []RichText{}
+
+	for i, cell := range cells {
+		if i > 0 {
+			text = append(text, newRichTextRun(" | ", *NewAnnotations(), ""))
+		}
+
+		runs := parseMarkdownInline(cell)
+		if header {
+			for j := range runs {
+				annotations := annotationsOf(&runs[j])
+				annotations.Bold = true
+				runs[j].SetAnnotations(annotations)
+			}
+		}
+		text = append(text, runs...)
+	}
+
+	return NewBlockParagraph(text)
+}
+
+func newRichTextRun(Content string, Annotations Annotations, URL string) RichText {
+	rt := NewRichText(Content)
+
+	text := &Text{Content: Content}
+	if len(URL) > 0 {
+		text.Link = &Link{URL: URL}
+		rt.JSON["href"] = URL
+	}
+	rt.SetText(text)
+	rt.SetAnnotations(&Annotations)
+
+	return *rt
+}
+
+// appendRichTextRun append text run, merged into previous run of same style
+func appendRichTextRun(runs []RichText, Content string, Annotations Annotations, URL string) []RichText {
+	if n := len(runs); n > 0 && runs[n-1].Type() == "text" {
+		prev := &runs[n-1]
+		if text, err := prev.GetText(); err == nil && *annotationsOf(prev) == Annotations {
+			link := ""
+			if text.Link != nil {
+				link = text.Link.URL
+			}
+			if link == URL {
+				runs[n-1] = newRichTextRun(text.Content+Content, Annotations, URL)
+				return runs
+			}
+		}
+	}
+
+	return append(runs, newRichTextRun(Content, Annotations, URL))
+}
+
+type markdownInline struct {
+	runs []RichText
+	buf  strings.Builder
+
+	annotations Annotations
+	link        string
+
+	//mention parse @user, used by ParseRichText
+	mention bool
+	users   map[string]string
+}
+
+// parseMarkdownInline convert inline GFM (emphasis, code span, strikethrough, link, image, $equation$) into rich text
+func parseMarkdownInline(s string) []RichText {
+	parser := &markdownInline{
+		runs:        []RichText{},
+		annotations: *NewAnnotations(),
+	}
+
+	parser.parse(s)
+	parser.flush()
+
+	return parser.runs
+}
+
+func (parser *markdownInline) flush() {
+	if parser.buf.Len() == 0 {
+		return
+	}
+
+	parser.runs = appendRichTextRun(parser.runs, parser.buf.String(), parser.annotations, parser.link)
+	parser.buf.Reset()
+}
+
+func (parser *markdownInline) toggle(flag *bool) {
+	parser.flush()
+	*flag = !*flag
+}
+
+func isPunct(r byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", r) >= 0
+}
+
+func isWordByte(r byte) bool {
+	return r >= 128 || r == '_' || ('0' <= r && r <= '9') || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z')
+}
+
+// closes check delimiter has closing pair in rest
+func closes(rest, delimiter string) bool {
+	i := strings.Index(rest, delimiter)
+	return i > 0 && rest[i-1] != ' '
+}
+
+func (parser *markdownInline) parse(s string) {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		rest := s[i:]
+
+		switch {
+		case c == '\\' && i+1 < len(s) && isPunct(s[i+1]):
+			parser.buf.WriteByte(s[i+1])
+			i++
+		case c == '`':
+			n := len(rest) - len(strings.TrimLeft(rest, "`"))
+			fence := rest[:n]
+			end := strings.Index(rest[n:], fence)
+			if end < 0 {
+				parser.buf.WriteString(fence)
+				i += n - 1
+				continue
+			}
+
+			code := rest[n : n+end]
+			if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.Trim(code, " ") != "" {
+				code = code[1 : len(code)-1]
+			}
+
+			parser.flush()
+			parser.annotations.Code = true
+			parser.buf.WriteString(code)
+			parser.flush()
+			parser.annotations.Code = false
+			i += n + end + n - 1
+		case c == '$' && len(rest) > 2 && rest[1] != ' ' && rest[1] != '$':
+			end := strings.Index(rest[1:], "$")
+			if end <= 0 || rest[end] == ' ' || (end+2 < len(rest) && '0' <= rest[end+2] && rest[end+2] <= '9') {
+				parser.buf.WriteByte(c)
+				continue
+			}
+
+			parser.flush()
+			rt := NewRichText(rest[1 : end+1])
+			rt.SetEquation(&Equation{Expression: rest[1 : end+1]})
+			annotations := parser.annotations
+			rt.SetAnnotations(&annotations)
+			parser.runs = append(parser.runs, *rt)
+			i += end + 1
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			if parser.annotations.Bold || closes(rest[2:], rest[:2]) {
+				parser.toggle(&parser.annotations.Bold)
+			} else {
+				parser.buf.WriteString(rest[:2])
+			}
+			i++
+		case strings.HasPrefix(rest, "~~"):
+			if parser.annotations.Strikethrough || closes(rest[2:], "~~") {
+				parser.toggle(&parser.annotations.Strikethrough)
+			} else {
+				parser.buf.WriteString("~~")
+			}
+			i++
+		case c == '*' || c == '_':
+			//intraword underscore is literal
+			if c == '_' && i > 0 && isWordByte(s[i-1]) && i+1 < len(s) && isWordByte(s[i+1]) {
+				parser.buf.WriteByte(c)
+				continue
+			}
+			if parser.annotations.Italic || (i+1 < len(s) && s[i+1] != ' ' && closes(rest[1:], string(c))) {
+				parser.toggle(&parser.annotations.Italic)
+			} else {
+				parser.buf.WriteByte(c)
+			}
+		case strings.HasPrefix(rest, "<u>"):
+			parser.toggle(&parser.annotations.Underline)
+			i += 2
+		case strings.HasPrefix(rest, "</u>"):
+			parser.toggle(&parser.annotations.Underline)
+			i += 3
+		case c == '<' && (strings.HasPrefix(rest, "<http://") || strings.HasPrefix(rest, "<https://") || strings.HasPrefix(rest, "<mailto:")):
+			end := strings.Index(rest, ">")
+			if end < 0 {
+				parser.buf.WriteByte(c)
+				continue
+			}
+
+			url := rest[1:end]
+			parser.flush()
+			parser.link = url
+			parser.buf.WriteString(url)
+			parser.flush()
+			parser.link = ""
+			i += end
+		case c == '[' || (c == '!' && strings.HasPrefix(rest, "![")):
+			offset := 1
+			if c == '!' {
+				offset = 2
+			}
+
+			label, url, n, ok := parseMarkdownLink(rest[offset:])
+			if !ok {
+				parser.buf.WriteString(rest[:offset])
+				i += offset - 1
+				continue
+			}
+			if len(label) == 0 {
+				label = url
+			}
+
+			parser.flush()
+			link := parser.link
+			parser.link = url
+			parser.parse(label)
+			parser.flush()
+			parser.link = link
+			i += offset + n - 1
+		case c == '@' && parser.mention && (i == 0 || !isWordByte(s[i-1])):
+			id, n := parser.user(rest[1:])
+			if n == 0 {
+				parser.buf.WriteByte(c)
+				continue
+			}
+
+			parser.flush()
+			rt := NewRichText(rest[:n+1])
+			rt.SetMentionUser(NewUser(id))
+			annotations := parser.annotations
+			rt.SetAnnotations(&annotations)
+			parser.runs = append(parser.runs, *rt)
+			i += n
+		default:
+			parser.buf.WriteByte(c)
+		}
+	}
+}
+
+// user find ID of user mentioned at the start of s, return ID and length of consumed string
+func (parser *markdownInline) user(s string) (string, int) {
+	if id := userIDPattern.FindString(s); len(id) > 0 && (len(id) == len(s) || !isWordByte(s[len(id)])) {
+		return id, len(id)
+	}
+
+	n := 0
+	for n < len(s) && (isWordByte(s[n]) || s[n] == '.' || s[n] == '-') {
+		n++
+	}
+	//trailing dot is end of sentence
+	for n > 0 && s[n-1] == '.' {
+		n--
+	}
+	if n == 0 {
+		return "", 0
+	}
+
+	if id, ok := parser.users[s[:n]]; ok {
+		return id, n
+	}
+
+	return "", 0
+}
+
+// parseMarkdownImage image block of paragraph which is only "![caption](url)" of http(s) URL, as MarkdownBlocks writes it
+func parseMarkdownImage(lines []string) (Block, bool) {
+	if len(lines) != 1 {
+		return nil, false
+	}
+
+	line := strings.TrimSpace(lines[0])
+	if !strings.HasPrefix(line, "![") {
+		return nil, false
+	}
+	caption, url, n, ok := parseMarkdownLink(line[2:])
+	if !ok || n != len(line)-2 || !(strings.HasPrefix(url, "https://") || strings.HasPrefix(url, "http://")) {
+		return nil, false
+	}
+
+	block := NewBlockImage(url)
+	if len(caption) > 0 {
+		image, _ := block.Json().GetJSON(TypeBlockImage)
+		list := []JSON{}
+		for _, rt := range parseMarkdownInline(caption) {
+			list = append(list, rt.JSON)
+		}
+		image["caption"] = list
+	}
+
+	return block, true
+}
+
+// parseMarkdownLink parse "label](url "title")" and return length of consumed string
+func parseMarkdownLink(s string) (label, url string, n int, ok bool) {
+	depth := 0
+	end := -1
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			if depth == 0 {
+				end = i
+			}
+			depth--
+		}
+		if end >= 0 {
+			break
+		}
+	}
+
+	if end < 0 || end+1 >= len(s) || s[end+1] != '(' {
+		return "", "", 0, false
+	}
+
+	close := strings.Index(s[end+2:], ")")
+	if close < 0 {
+		return "", "", 0, false
+	}
+
+	target := strings.TrimSpace(s[end+2 : end+2+close])
+	if i := strings.IndexAny(target, " \t"); i > 0 {
+		target = target[:i]
+	}
+	target = strings.TrimSuffix(strings.TrimPrefix(target, "<"), ">")
+
+	return s[:end], target, end + 2 + close + 1, true
+}
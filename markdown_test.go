@@ -0,0 +1,77 @@
+package notion
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// markdownNodes nodes of blocks with nested children, as returned by FetchTree
+func markdownNodes(blocks []Block, depth int) []*BlockNode {
+	nodes := []*BlockNode{}
+	for _, block := range blocks {
+		nodes = append(nodes, &BlockNode{Block: block, Depth: depth, Children: markdownNodes(BlockChildren(block), depth+1)})
+	}
+
+	return nodes
+}
+
+func TestMarkdownRoundTrip(t *testing.T) {
+	tests := []struct {
+		source string
+		//markdown rendered from parsed blocks, empty is same as source
+		markdown string
+	}{
+		{source: "# Title"},
+		{source: "## Section\n\n### Subsection"},
+		{source: "Plain paragraph"},
+		{source: "first line\\\nsecond line"},
+		{source: "**bold** _italic_ ~~strike~~ `code` [link](https://example.com)"},
+		{source: "*italic*", markdown: "_italic_"},
+		{source: "Escaped \\*star\\*"},
+		{source: "- one\n- two\n  - nested"},
+		{source: "1. first\n2. second"},
+		{source: "- [ ] todo\n- [x] done"},
+		{source: "<details>\n<summary>Toggle</summary>\n\ninside\n\n</details>"},
+		{source: "![caption](https://example.com/a.png)"},
+		{source: "```\nfmt.Println(1)\nfmt.Println(2)\n```"},
+		{source: "> quote", markdown: "quote"},
+		{source: "before\n\n---\n\nafter", markdown: "before\n\nafter"},
+		{source: "| a | b |\n| --- | --- |\n| 1 | 2 |", markdown: "**a** \\| **b**\n\n1 \\| 2"},
+	}
+
+	for _, test := range tests {
+		want := test.markdown
+		if len(want) == 0 {
+			want = test.source
+		}
+
+		got := MarkdownBlocks(markdownNodes(ParseMarkdown(test.source), 1), nil)
+		if strings.TrimSpace(got) != want {
+			t.Errorf("%q: got %q, want %q", test.source, got, want)
+		}
+	}
+}
+
+func TestMarkdownPage(t *testing.T) {
+	page := &Page{JSON: JSON{}}
+	source := `{"object":"page","id":"p1","properties":{"Name":{"id":"title","type":"title","title":[{"type":"text","text":{"content":"Notes"},"plain_text":"Notes","annotations":{}}]}}}`
+	if err := json.Unmarshal([]byte(source), &page.JSON); err != nil {
+		t.Fatal(err)
+	}
+
+	child := JSON{"object": "block", "id": "c1", "type": TypeBlockChildPage, TypeBlockChildPage: JSON{"title": "Child"}}
+	nodes := markdownNodes(append(ParseMarkdown("Some *text*"), NewBlock(child)), 1)
+
+	got := MarkdownPage(page, nodes, &MarkdownOption{
+		PageURL: func(PageID string) string { return "./" + PageID + ".md" },
+	})
+	want := "# Notes\n\nSome _text_\n\n[Child](./c1.md)\n"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if got := MarkdownPage(nil, nil, nil); got != "" {
+		t.Errorf("empty page: got %q", got)
+	}
+}
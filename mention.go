@@ -0,0 +1,216 @@
+package notion
+
+import (
+	"fmt"
+	"sync"
+)
+
+const (
+	MentionTypeUser            = "user"
+	MentionTypePage            = "page"
+	MentionTypeDatabase        = "database"
+	MentionTypeDate            = "date"
+	MentionTypeLinkPreview     = "link_preview"
+	MentionTypeTemplateMention = "template_mention"
+)
+
+type LinkPreview struct {
+	URL string `json:"url"`
+}
+
+type TemplateMention struct {
+	Type                string `json:"type"`
+	TemplateMentionDate string `json:"template_mention_date,omitempty"`
+	TemplateMentionUser string `json:"template_mention_user,omitempty"`
+}
+
+// Mention typed mention object, only the field of Type is set
+type Mention struct {
+	Type string
+
+	User            *User
+	Page            *Page
+	Database        *Database
+	Date            *Date
+	LinkPreview     *LinkPreview
+	TemplateMention *TemplateMention
+}
+
+// MentionType return type of mention, empty if rich text is not mention
+func (rt *RichText) MentionType() string {
+	if rt.Type() != "mention" {
+		return ""
+	}
+
+	mention, ok := rt.JSON.GetJSON("mention")
+	if !ok {
+		return ""
+	}
+
+	return mention.GetString("type")
+}
+
+func (rt *RichText) GetMention() (*Mention, error) {
+	mention := &Mention{Type: rt.MentionType()}
+
+	var err error
+	switch mention.Type {
+	case MentionTypeUser:
+		mention.User, err = rt.GetMentionUser()
+	case MentionTypePage:
+		mention.Page, err = rt.GetMentionPage()
+	case MentionTypeDatabase:
+		mention.Database, err = rt.GetMentionDatabase()
+	case MentionTypeDate:
+		mention.Date, err = rt.GetMentionDate()
+	case MentionTypeLinkPreview:
+		mention.LinkPreview, err = rt.GetMentionLinkPreview()
+	case MentionTypeTemplateMention:
+		mention.TemplateMention, err = rt.GetMentionTemplate()
+	case "":
+		return nil, fmt.Errorf("type of RichText is not 'mention'")
+	default:
+		return nil, fmt.Errorf("unknown mention type: '%s'", mention.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return mention, nil
+}
+
+func (rt *RichText) GetMentionLinkPreview() (*LinkPreview, error) {
+	preview := &LinkPreview{}
+	if err := rt.getMention(MentionTypeLinkPreview, preview); err != nil {
+		return nil, err
+	}
+
+	return preview, nil
+}
+
+func (rt *RichText) GetMentionTemplate() (*TemplateMention, error) {
+	template := &TemplateMention{}
+	if err := rt.getMention(MentionTypeTemplateMention, template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (rt *RichText) SetMentionTemplate(template *TemplateMention) error {
+	return rt.setMention(MentionTypeTemplateMention, template)
+}
+
+// TreeRichText collect rich text of all blocks in tree
+func TreeRichText(nodes []*BlockNode) []RichText {
+	list := []RichText{}
+
+	WalkTree(nodes, func(node *BlockNode) bool {
+		if rtb, ok := richTextBlock(node.Block); ok {
+			if text, err := rtb.ListText(); err == nil {
+				list = append(list, text...)
+			}
+		}
+		return true
+	})
+
+	return list
+}
+
+// ResolvedMentions objects of mentions, failures of inaccessible objects are kept in Errors by ID
+type ResolvedMentions struct {
+	Users     map[string]*User
+	Pages     map[string]*Page
+	Databases map[string]*Database
+	Errors    map[string]error
+}
+
+// ResolveMentions retrieve users, pages and databases mentioned in text. each ID is fetched once, in parallel
+func (notion *Notion) ResolveMentions(Text []RichText) (*ResolvedMentions, error) {
+	if notion.invalid() {
+		return nil, fmt.Errorf("Nil pointer API Implementation")
+	}
+
+	resolved := &ResolvedMentions{
+		Users:     map[string]*User{},
+		Pages:     map[string]*Page{},
+		Databases: map[string]*Database{},
+		Errors:    map[string]error{},
+	}
+
+	type request struct {
+		t  string
+		id string
+	}
+	requests := []request{}
+	seen := map[request]bool{}
+
+	for i := range Text {
+		mention, err := Text[i].GetMention()
+		if err != nil {
+			continue
+		}
+
+		r := request{t: mention.Type}
+		switch mention.Type {
+		case MentionTypeUser:
+			r.id = mention.User.ID
+		case MentionTypePage:
+			r.id = mention.Page.ID()
+		case MentionTypeDatabase:
+			r.id = mention.Database.ID()
+		default:
+			continue
+		}
+
+		if len(r.id) > 0 && !seen[r] {
+			seen[r] = true
+			requests = append(requests, r)
+		}
+	}
+
+	mutex := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	sem := make(chan struct{}, 4)
+
+	for _, r := range requests {
+		wg.Add(1)
+		go func(r request) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			var err error
+			var user *User
+			var page *Page
+			var database *Database
+
+			switch r.t {
+			case MentionTypeUser:
+				user, err = notion.api.RetrieveUser(r.id)
+			case MentionTypePage:
+				page, err = notion.api.RetrievePage(r.id)
+			case MentionTypeDatabase:
+				database, err = notion.api.RetrieveDatabase(r.id)
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			switch {
+			case err != nil:
+				resolved.Errors[r.id] = err
+			case user != nil:
+				resolved.Users[r.id] = user
+			case page != nil:
+				resolved.Pages[r.id] = page
+			case database != nil:
+				resolved.Databases[r.id] = database
+			}
+		}(r)
+	}
+	wg.Wait()
+
+	return resolved, nil
+}
@@ -46,8 +46,11 @@ func TimeFormat(t time.Time) string {
 	return t.Format("2006-01-02T15:04:05.000Z")
 }
 
+// ParseTime parse timestamp of Notion, date-only and values without offset are read as UTC. see ParseDate
 func ParseTime(t string) (time.Time, error) {
-	return time.Parse("2006-01-02T15:04:05.000Z", t)
+	tm, _, err := ParseDate(t, time.UTC)
+
+	return tm, err
 }
 
 type Notion struct {
@@ -62,6 +65,11 @@ func New(API API) *Notion {
 	return notion
 }
 
+// unsupported error of endpoint which API does not implement
+func (notion *Notion) unsupported(Endpoint string) error {
+	return fmt.Errorf("%s: %w %s", Endpoint, ErrUnsupportedVersion, notion.api.Version())
+}
+
 func (notion *Notion) invalid() bool {
 	if notion.api == nil {
 		return true
@@ -106,6 +114,20 @@ func (notion *Notion) AppendBlockChildren(BlockID string, Children []Block) (Blo
 	return notion.api.AppendBlockChildren(BlockID, Children)
 }
 
+// DeleteBlock archive block, deleting child_page block archives the page
+func (notion *Notion) DeleteBlock(BlockID string) (Block, error) {
+	if notion.invalid() {
+		return nil, fmt.Errorf("Nil pointer API Implementation")
+	}
+
+	api, ok := notion.api.(BlockDeleteAPI)
+	if !ok {
+		return nil, notion.unsupported("delete block")
+	}
+
+	return api.DeleteBlock(BlockID)
+}
+
 func (notion *Notion) RetrievePage(PageID string) (*Page, error) {
 	if notion.invalid() {
 		return nil, fmt.Errorf("Nil pointer APi Implementation")
@@ -130,6 +152,18 @@ func (notion *Notion) UpdatePageProperties(PageID string, Properties ...Property
 	return notion.api.UpdatePageProperties(PageID, Properties...)
 }
 
+func (notion *Notion) RetrievePagePropertyItem(PageID, PropertyID string, Pagination *PaginationRequest) (*PropertyItem, error) {
+	if notion.invalid() {
+		return nil, fmt.Errorf("Nil pointer API Implementation")
+	}
+	api, ok := notion.api.(PropertyItemAPI)
+	if !ok {
+		return nil, notion.unsupported("page property items")
+	}
+
+	return api.RetrievePagePropertyItem(PageID, PropertyID, Pagination)
+}
+
 func (notion *Notion) RetrieveDatabase(DatabaseID string) (*Database, error) {
 	if notion.invalid() {
 		return nil, fmt.Errorf("Nil pointer APi Implementation")
@@ -154,6 +188,34 @@ func (notion *Notion) ListDatabases(Pagination *PaginationRequest) (*PaginationR
 	return notion.api.ListDatabases(Pagination)
 }
 
+// CreateDatabase create database in page, Properties must have one title
+func (notion *Notion) CreateDatabase(Parent *Parent, Title []RichText, Properties []Configuration) (*Database, error) {
+	if notion.invalid() {
+		return nil, fmt.Errorf("Nil pointer API Implementation")
+	}
+
+	api, ok := notion.api.(DatabaseWriteAPI)
+	if !ok {
+		return nil, notion.unsupported("create database")
+	}
+
+	return api.CreateDatabase(Parent, Title, Properties)
+}
+
+// UpdateDatabase add or change properties of database, Title is not changed when it is nil
+func (notion *Notion) UpdateDatabase(DatabaseID string, Title []RichText, Properties []Configuration) (*Database, error) {
+	if notion.invalid() {
+		return nil, fmt.Errorf("Nil pointer API Implementation")
+	}
+
+	api, ok := notion.api.(DatabaseWriteAPI)
+	if !ok {
+		return nil, notion.unsupported("update database")
+	}
+
+	return api.UpdateDatabase(DatabaseID, Title, Properties)
+}
+
 func (notion *Notion) Search(Query string, Pagination *PaginationRequest, Filter Object, Sort *Sort) (*PaginationResponse, error) {
 	if notion.invalid() {
 		return nil, fmt.Errorf("Nil pointer APi Implementation")
@@ -0,0 +1,283 @@
+package notion
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var numberPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+type numberCurrency struct {
+	symbol   string
+	decimals int
+}
+
+// numberCurrencies currency formats of number configuration
+var numberCurrencies = map[string]numberCurrency{
+	"dollar":             {"$", 2},
+	"canadian_dollar":    {"CA$", 2},
+	"euro":               {"€", 2},
+	"pound":              {"£", 2},
+	"yen":                {"¥", 0},
+	"ruble":              {"₽", 2},
+	"rupee":              {"₹", 2},
+	"won":                {"₩", 0},
+	"yuan":               {"CN¥", 2},
+	"real":               {"R$", 2},
+	"lira":               {"₺", 2},
+	"rupiah":             {"Rp", 2},
+	"franc":              {"CHF ", 2},
+	"hong_kong_dollar":   {"HK$", 2},
+	"new_zealand_dollar": {"NZ$", 2},
+	"krona":              {"kr ", 2},
+	"norwegian_krone":    {"kr ", 2},
+	"mexican_peso":       {"MX$", 2},
+	"rand":               {"R ", 2},
+	"new_taiwan_dollar":  {"NT$", 2},
+	"danish_krone":       {"kr ", 2},
+	"zloty":              {"zł ", 2},
+	"baht":               {"฿", 2},
+	"forint":             {"Ft ", 2},
+	"koruna":             {"Kč ", 2},
+	"shekel":             {"₪", 2},
+	"chilean_peso":       {"CLP$", 0},
+	"philippine_peso":    {"₱", 2},
+	"dirham":             {"AED ", 2},
+	"colombian_peso":     {"COP$", 2},
+	"riyal":              {"SAR ", 2},
+	"ringgit":            {"RM", 2},
+	"leu":                {"lei ", 2},
+}
+
+// numberSymbols symbols of currencies trimmed by ParseNumber, longer symbols first as they contain shorter ones,
+// e.g. "CA$" and "$"
+var numberSymbols []string
+
+func init() {
+	for _, currency := range numberCurrencies {
+		numberSymbols = append(numberSymbols, strings.TrimSpace(currency.symbol))
+	}
+	sort.Slice(numberSymbols, func(i, j int) bool {
+		if len(numberSymbols[i]) != len(numberSymbols[j]) {
+			return len(numberSymbols[i]) > len(numberSymbols[j])
+		}
+		return numberSymbols[i] < numberSymbols[j]
+	})
+}
+
+// FormatNumber render number as Notion shows it in the format of number configuration,
+// e.g. "dollar" -> "$1,234.50", "percent" -> 0.25 is "25%", "number_with_commas" -> "1,234.5"
+func FormatNumber(Value float64, Format string) string {
+	if math.IsNaN(Value) || math.IsInf(Value, 0) {
+		return strconv.FormatFloat(Value, 'f', -1, 64)
+	}
+
+	return FormatDecimal(json.Number(strconv.FormatFloat(Value, 'f', -1, 64)), Format)
+}
+
+// FormatDecimal same as FormatNumber, but digits of number are kept exactly and currencies are rounded half away from zero
+func FormatDecimal(Value json.Number, Format string) string {
+	s := string(Value)
+	if !numberPattern.MatchString(s) {
+		return s
+	}
+	s = expandExponent(s)
+
+	switch Format {
+	case "number_with_commas":
+		return groupDigits(s)
+	case "percent":
+		return shiftDecimal(s, 2) + "%"
+	}
+
+	currency, ok := numberCurrencies[Format]
+	if !ok {
+		return s
+	}
+
+	digits := roundDecimal(s, currency.decimals)
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+
+	return sign + currency.symbol + groupDigits(digits)
+}
+
+// expandExponent write decimal string of exponent notation without exponent, e.g. "1.5e3" is "1500"
+func expandExponent(s string) string {
+	i := strings.IndexAny(s, "eE")
+	if i < 0 {
+		return s
+	}
+
+	exponent, err := strconv.Atoi(s[i+1:])
+	if err != nil || exponent > 1000 || exponent < -1000 {
+		f, _ := strconv.ParseFloat(s, 64)
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	return shiftDecimal(s[:i], exponent)
+}
+
+// roundDecimal round decimal string to places of fraction, half away from zero
+func roundDecimal(s string, places int) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+
+	integer, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		integer, fraction = s[:i], s[i+1:]
+	}
+	for len(fraction) <= places {
+		fraction += "0"
+	}
+
+	digits := []byte(integer + fraction[:places])
+	if fraction[places] >= '5' {
+		i := len(digits) - 1
+		for ; i >= 0 && digits[i] == '9'; i-- {
+			digits[i] = '0'
+		}
+		if i >= 0 {
+			digits[i]++
+		} else {
+			digits = append([]byte{'1'}, digits...)
+		}
+	}
+
+	integer = string(digits[:len(digits)-places])
+	if places > 0 {
+		integer += "." + string(digits[len(digits)-places:])
+	}
+	if strings.Trim(integer, "0.") == "" {
+		sign = ""
+	}
+
+	return sign + integer
+}
+
+// groupDigits separate thousands of integer part with comma
+func groupDigits(s string) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+
+	integer, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		integer, fraction = s[:i], s[i:]
+	}
+
+	b := &strings.Builder{}
+	for i, r := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+
+	return sign + b.String() + fraction
+}
+
+// shiftDecimal multiply decimal string by 10^n without rounding error, negative n divides
+func shiftDecimal(s string, n int) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+
+	integer, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		integer, fraction = s[:i], s[i+1:]
+	}
+	if n >= 0 {
+		for len(fraction) < n {
+			fraction += "0"
+		}
+		integer, fraction = integer+fraction[:n], fraction[n:]
+	} else {
+		for len(integer) < -n {
+			integer = "0" + integer
+		}
+		integer, fraction = integer[:len(integer)+n], integer[len(integer)+n:]+fraction
+	}
+
+	integer = strings.TrimLeft(integer, "0")
+	fraction = strings.TrimRight(fraction, "0")
+	if len(integer) == 0 {
+		integer = "0"
+	}
+	if len(fraction) > 0 {
+		integer += "." + fraction
+	}
+	if integer == "0" {
+		sign = ""
+	}
+
+	return sign + integer
+}
+
+// ParseNumber read number written in the format of number configuration or as plain number,
+// e.g. "$1,234.50" is 1234.50, "25%" of "percent" is 0.25, "(12)" is -12
+func ParseNumber(Value string, Format string) (json.Number, error) {
+	s := strings.TrimSpace(Value)
+
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative, s = true, strings.TrimSpace(s[1:len(s)-1])
+	}
+	if strings.HasPrefix(s, "-") {
+		negative, s = !negative, strings.TrimSpace(s[1:])
+	}
+
+	percent := Format == "percent" && strings.HasSuffix(s, "%")
+	if percent {
+		s = strings.TrimSpace(s[:len(s)-1])
+	}
+
+	// symbol of format before all
+	symbols := numberSymbols
+	if currency, ok := numberCurrencies[Format]; ok {
+		symbols = append([]string{strings.TrimSpace(currency.symbol)}, numberSymbols...)
+	}
+	trimmed := false
+	for _, symbol := range symbols {
+		if strings.HasPrefix(s, symbol) {
+			s, trimmed = strings.TrimSpace(s[len(symbol):]), true
+			break
+		} else if strings.HasSuffix(s, symbol) {
+			s, trimmed = strings.TrimSpace(s[:len(s)-len(symbol)]), true
+			break
+		}
+	}
+	// sign may follow symbol, e.g. "$-3"
+	if trimmed && strings.HasPrefix(s, "-") {
+		negative, s = !negative, s[1:]
+	}
+
+	s = strings.NewReplacer(",", "", " ", "", "_", "").Replace(s)
+	if strings.HasPrefix(s, ".") {
+		s = "0" + s
+	}
+	if !numberPattern.MatchString(s) || strings.HasPrefix(s, "-") {
+		return "", fmt.Errorf("invalid number: '%s'", Value)
+	}
+	s = expandExponent(s)
+
+	if percent {
+		s = shiftDecimal(s, -2)
+	}
+	if negative && strings.Trim(s, "0.") != "" {
+		s = "-" + s
+	}
+
+	return json.Number(s), nil
+}
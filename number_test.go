@@ -0,0 +1,100 @@
+package notion
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		value  string
+		format string
+		want   string
+	}{
+		{"12", "number", "12"},
+		{"R$ 12", "number", "12"},
+		{"R$ 12", "real", "12"},
+		{"R 12", "number", "12"},
+		{"CA$1,234.50", "number", "1234.50"},
+		{"$1,234.50", "dollar", "1234.50"},
+		{"CA$5", "dollar", "5"},
+		{"12 kr", "krona", "12"},
+		{"CHF 3.5", "franc", "3.5"},
+		{"25%", "percent", "0.25"},
+		{"2.5 %", "percent", "0.025"},
+		{"100%", "percent", "1"},
+		{"25", "percent", "25"},
+		{"(12)", "number", "-12"},
+		{"-$3", "dollar", "-3"},
+		{"$-3", "dollar", "-3"},
+		{".5", "number", "0.5"},
+		{"-0", "number", "0"},
+		{"1_000", "number_with_commas", "1000"},
+		{"1e3", "number", "1000"},
+		{"1.5E-3", "number", "0.0015"},
+		{"1.25e30", "number", "1250000000000000000000000000000"},
+		{"12345678901234567.25", "number", "12345678901234567.25"},
+	}
+	for _, test := range tests {
+		got, err := ParseNumber(test.value, test.format)
+		if err != nil || string(got) != test.want {
+			t.Errorf("ParseNumber(%q, %q) = %q, %v, want %q", test.value, test.format, got, err, test.want)
+		}
+	}
+
+	for _, test := range []struct{ value, format string }{
+		{"25%", "number"}, {"abc", "number"}, {"", "number"}, {"1.2.3", "number"}, {"--1", "number"},
+	} {
+		if got, err := ParseNumber(test.value, test.format); err == nil {
+			t.Errorf("ParseNumber(%q, %q) = %q, want error", test.value, test.format, got)
+		}
+	}
+}
+
+func TestFormatDecimal(t *testing.T) {
+	tests := []struct {
+		value  string
+		format string
+		want   string
+	}{
+		{"1.005", "dollar", "$1.01"},
+		{"-1.005", "dollar", "-$1.01"},
+		{"999.995", "dollar", "$1,000.00"},
+		{"-0.001", "dollar", "$0.00"},
+		{"1e2", "dollar", "$100.00"},
+		{"0.125", "yen", "¥0"},
+		{"0.5", "yen", "¥1"},
+		{"12345678901234567890.5", "won", "₩12,345,678,901,234,567,891"},
+		{"1.5e3", "number", "1500"},
+		{"1.5E-3", "number", "0.0015"},
+		{"0.1", "percent", "10%"},
+		{"-2e-2", "percent", "-2%"},
+	}
+	for _, test := range tests {
+		if got := FormatDecimal(json.Number(test.value), test.format); got != test.want {
+			t.Errorf("FormatDecimal(%q, %q) = %q, want %q", test.value, test.format, got, test.want)
+		}
+	}
+}
+
+func TestShiftDecimal(t *testing.T) {
+	tests := []struct {
+		value string
+		n     int
+		want  string
+	}{
+		{"1.5", 2, "150"},
+		{"120", 0, "120"},
+		{"25", -2, "0.25"},
+		{"-2.5", -2, "-0.025"},
+		{"100", -2, "1"},
+		{"0", -3, "0"},
+		{"007", -2, "0.07"},
+		{"12.5", -1, "1.25"},
+	}
+	for _, test := range tests {
+		if got := shiftDecimal(test.value, test.n); got != test.want {
+			t.Errorf("shiftDecimal(%q, %d) = %q, want %q", test.value, test.n, got, test.want)
+		}
+	}
+}
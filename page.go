@@ -1,9 +1,11 @@
 package notion
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -26,6 +28,13 @@ const (
 	TypePropertyURL         = "url"
 	TypePropertyEmail       = "email"
 	TypePropertyPhoneNumber = "phone_number"
+	TypePropertyRelation    = "relation"
+	TypePropertyRollup      = "rollup"
+
+	TypePropertyCreatedTime    = "created_time"
+	TypePropertyLastEditedTime = "last_edited_time"
+	TypePropertyCreatedBy      = "created_by"
+	TypePropertyLastEditedBy   = "last_edited_by"
 )
 
 type Page struct {
@@ -109,6 +118,16 @@ func (page *Page) Properties() []Property {
 	return properties
 }
 
+func (page *Page) Title() []RichText {
+	for _, property := range page.Properties() {
+		if title, ok := property.(*PropertyTitle); ok {
+			return title.RichText()
+		}
+	}
+
+	return nil
+}
+
 type Parent struct {
 	ID string
 
@@ -174,12 +193,35 @@ func AssignProperty(name string, json JSON) (Property, error) {
 		property = &PropertyEmail{&BaseProperty{name: name, JSON: json}}
 	case TypePropertyPhoneNumber:
 		property = &PropertyPhoneNumber{&BaseProperty{name: name, JSON: json}}
+	case TypePropertyRelation:
+		property = &PropertyRelation{&BaseProperty{name: name, JSON: json}}
+	case TypePropertyRollup:
+		property = &PropertyRollup{&BaseProperty{name: name, JSON: json}}
+	case TypePropertyCreatedTime:
+		property = &PropertyCreatedTime{&TimeProperty{&BaseProperty{name: name, JSON: json}}}
+	case TypePropertyLastEditedTime:
+		property = &PropertyLastEditedTime{&TimeProperty{&BaseProperty{name: name, JSON: json}}}
+	case TypePropertyCreatedBy:
+		property = &PropertyCreatedBy{&UserProperty{&BaseProperty{name: name, JSON: json}}}
+	case TypePropertyLastEditedBy:
+		property = &PropertyLastEditedBy{&UserProperty{&BaseProperty{name: name, JSON: json}}}
 	default:
 		return nil, fmt.Errorf("invalid type: '%s'", t)
 	}
 	return property, nil
 }
 
+// IsReadOnlyProperty check value of property is computed by Notion and can not be written
+func IsReadOnlyProperty(property Property) bool {
+	switch property.Type() {
+	case TypePropertyCreatedTime, TypePropertyLastEditedTime, TypePropertyCreatedBy, TypePropertyLastEditedBy, TypePropertyRollup,
+		TypePropertyFormula:
+		return true
+	}
+
+	return false
+}
+
 type BaseProperty struct {
 	name string
 
@@ -230,7 +272,7 @@ func newRichTextProperty(Name, ID, Type string, Text []RichText) *RichTextProper
 		BaseProperty: newBaseProperty(Name, ID, Type, []JSON{}),
 	}
 
-	for _, t := range Text {
+	for _, t := range SplitRichText(Text) {
 		property.JSON.Append(Type, t.JSON)
 	}
 
@@ -303,14 +345,49 @@ func NewPropertyNumber(Name string, Number int) Property {
 	return property
 }
 
+// NewPropertyNumberFloat number property with decimal value
+func NewPropertyNumberFloat(Name string, Number float64) Property {
+	property := &PropertyNumber{
+		BaseProperty: newBaseProperty(Name, "", "number", json.Number(strconv.FormatFloat(Number, 'f', -1, 64))),
+	}
+	return property
+}
+
+// NewPropertyNumberDecimal number property with value written exactly as Decimal, e.g. "19.99"
+func NewPropertyNumberDecimal(Name string, Decimal json.Number) (Property, error) {
+	if !numberPattern.MatchString(string(Decimal)) {
+		return nil, fmt.Errorf("invalid number: '%s'", Decimal)
+	}
+
+	property := &PropertyNumber{
+		BaseProperty: newBaseProperty(Name, "", "number", Decimal),
+	}
+	return property, nil
+}
+
 func (property *PropertyNumber) Interface() interface{} {
 	return property
 }
 
+// Number return value truncated to integer, use Float or Decimal for decimal value
 func (property *PropertyNumber) Number() (int, error) {
 	return property.JSON.GetInt("number"), nil
 }
 
+func (property *PropertyNumber) Float() (float64, error) {
+	n := property.JSON.GetNumber("number")
+	if len(n) == 0 {
+		return 0, fmt.Errorf("number of property '%s' is empty", property.Name())
+	}
+
+	return n.Float64()
+}
+
+// Decimal return value as it is written in JSON, empty if number is not set
+func (property *PropertyNumber) Decimal() json.Number {
+	return property.JSON.GetNumber("number")
+}
+
 type PropertySelect struct {
 	*BaseProperty
 }
@@ -387,8 +464,15 @@ type PropertyDate struct {
 }
 
 func NewPropertyDate(Name string, Date *Date) Property {
+	var v interface{}
+	if Date != nil {
+		j := JSON{}
+		j.Marshal(Date)
+		v = j
+	}
+
 	property := &PropertyDate{
-		BaseProperty: newBaseProperty(Name, "", "date", Date),
+		BaseProperty: newBaseProperty(Name, "", "date", v),
 	}
 
 	return property
@@ -428,14 +512,23 @@ func NewPropertyFormula(Name string, v interface{}) Property {
 		i, _ := strconv.ParseUint(s, 10, 64)
 		j["type"] = "number"
 		j["number"] = i
+	case float64, float32:
+		f, _ := strconv.ParseFloat(fmt.Sprint(v), 64)
+		j["type"] = "number"
+		j["number"] = json.Number(strconv.FormatFloat(f, 'f', -1, 64))
+	case json.Number:
+		j["type"] = "number"
+		j["number"] = v
 	case bool:
 		s := fmt.Sprint(v)
 		b, _ := strconv.ParseBool(s)
 		j["type"] = "boolean"
 		j["boolean"] = b
 	case *Date, Date:
+		jj := JSON{}
+		jj.Marshal(v)
 		j["type"] = "date"
-		j["date"] = v
+		j["date"] = jj
 	default:
 		j["type"] = "string"
 		j["string"] = fmt.Sprint(v)
@@ -452,6 +545,28 @@ func (property *PropertyFormula) Interface() interface{} {
 	return property
 }
 
+// Number return result of number formula with decimal
+func (property *PropertyFormula) Number() (float64, bool) {
+	n := property.Decimal()
+	if len(n) == 0 {
+		return 0, false
+	}
+
+	f, err := n.Float64()
+	return f, err == nil
+}
+
+// Decimal return result of number formula as it is written in JSON, empty if result is not number
+func (property *PropertyFormula) Decimal() json.Number {
+	j, ok := property.JSON.GetJSON(property.Type())
+	if !ok || j.GetString("type") != "number" {
+		return ""
+	}
+
+	return j.GetNumber("number")
+}
+
+// Formula return type and value of result, number is truncated to int, use Number or Decimal for decimal
 func (property *PropertyFormula) Formula() (Type string, v interface{}) {
 	j, ok := property.JSON.GetJSON(property.Type())
 	if !ok {
@@ -640,3 +755,180 @@ func (property *PropertyPhoneNumber) Interface() interface{} {
 func (property *PropertyPhoneNumber) PhoneNumber() string {
 	return property.JSON.GetString(property.Type())
 }
+
+type PropertyRelation struct {
+	*BaseProperty
+}
+
+func NewPropertyRelation(Name string, PageIDs ...string) Property {
+	list := []JSON{}
+
+	for _, id := range PageIDs {
+		list = append(list, JSON{"id": id})
+	}
+
+	property := &PropertyRelation{
+		BaseProperty: newBaseProperty(Name, "", "relation", list),
+	}
+
+	return property
+}
+
+func (property *PropertyRelation) Interface() interface{} {
+	return property
+}
+
+// PageIDs return IDs of related pages
+func (property *PropertyRelation) PageIDs() []string {
+	j, ok := property.JSON.GetJSONList(property.Type())
+	if !ok {
+		return nil
+	}
+
+	list := []string{}
+
+	for _, jj := range j {
+		list = append(list, jj.GetString("id"))
+	}
+
+	return list
+}
+
+// PropertyRollup read-only result of rollup, one of number, date and array
+type PropertyRollup struct {
+	*BaseProperty
+}
+
+func (property *PropertyRollup) Interface() interface{} {
+	return property
+}
+
+func (property *PropertyRollup) rollup() JSON {
+	j, _ := property.JSON.GetJSON(property.Type())
+
+	return j
+}
+
+// RollupType return type of result: "number", "date" or "array"
+func (property *PropertyRollup) RollupType() string {
+	return property.rollup().GetString("type")
+}
+
+// Function return name of rollup function, e.g. "sum", "count"
+func (property *PropertyRollup) Function() string {
+	return property.rollup().GetString("function")
+}
+
+func (property *PropertyRollup) Number() (float64, bool) {
+	j := property.rollup()
+	if j.GetString("type") != "number" || j.Get("number") == nil {
+		return 0, false
+	}
+
+	return j.GetFloat("number"), true
+}
+
+// Decimal return result of number rollup as it is written in JSON, empty if result is not number
+func (property *PropertyRollup) Decimal() json.Number {
+	j := property.rollup()
+	if j.GetString("type") != "number" {
+		return ""
+	}
+
+	return j.GetNumber("number")
+}
+
+func (property *PropertyRollup) Date() *Date {
+	j, ok := property.rollup().GetJSON("date")
+	if !ok {
+		return nil
+	}
+
+	date := &Date{}
+	if j.Unmarshal(date) != nil {
+		return nil
+	}
+	return date
+}
+
+// Array return values of rollup as properties without name
+func (property *PropertyRollup) Array() []Property {
+	j, ok := property.rollup().GetJSONList("array")
+	if !ok {
+		return nil
+	}
+
+	list := []Property{}
+
+	for _, jj := range j {
+		if p, err := AssignProperty("", jj); err == nil {
+			list = append(list, p)
+		}
+	}
+
+	return list
+}
+
+type TimeProperty struct {
+	*BaseProperty
+}
+
+func (property *TimeProperty) Interface() interface{} {
+	return property
+}
+
+func (property *TimeProperty) Time() (time.Time, error) {
+	return ParseTime(property.JSON.GetString(property.Type()))
+}
+
+type PropertyCreatedTime struct {
+	*TimeProperty
+}
+
+func (property *PropertyCreatedTime) Interface() interface{} {
+	return property
+}
+
+type PropertyLastEditedTime struct {
+	*TimeProperty
+}
+
+func (property *PropertyLastEditedTime) Interface() interface{} {
+	return property
+}
+
+type UserProperty struct {
+	*BaseProperty
+}
+
+func (property *UserProperty) Interface() interface{} {
+	return property
+}
+
+func (property *UserProperty) User() *User {
+	j, ok := property.JSON.GetJSON(property.Type())
+	if !ok {
+		return nil
+	}
+
+	return &User{
+		ID:   j.GetString("id"),
+		JSON: j,
+	}
+}
+
+type PropertyCreatedBy struct {
+	*UserProperty
+}
+
+func (property *PropertyCreatedBy) Interface() interface{} {
+	return property
+}
+
+type PropertyLastEditedBy struct {
+	*UserProperty
+}
+
+func (property *PropertyLastEditedBy) Interface() interface{} {
+	return property
+}
@@ -0,0 +1,158 @@
+package notion
+
+import "fmt"
+
+// PropertyItemVersion first version of API which has endpoint of page property items
+const PropertyItemVersion = "2022-02-22"
+
+// PropertyItem response of retrieving property of page.
+// title, rich_text, relation, people and rollup are paginated list of items, others are one item
+type PropertyItem struct {
+	JSON JSON
+}
+
+// IsList check item is paginated list
+func (item *PropertyItem) IsList() bool {
+	return item.JSON.GetString("object") == "list"
+}
+
+// Type return type of property
+func (item *PropertyItem) Type() string {
+	if item.IsList() {
+		if j, ok := item.JSON.GetJSON("property_item"); ok {
+			return j.GetString("type")
+		}
+	}
+
+	return item.JSON.GetString("type")
+}
+
+func (item *PropertyItem) HasMore() bool {
+	return item.JSON.GetBool("has_more")
+}
+
+func (item *PropertyItem) NextCursor() string {
+	return item.JSON.GetString("next_cursor")
+}
+
+// Results return items of list
+func (item *PropertyItem) Results() []JSON {
+	results, _ := item.JSON.GetJSONList("results")
+
+	return results
+}
+
+// SupportsPropertyItems check API implements PropertyItemAPI, e.g. v20220222 does and v20210513 does not
+func (notion *Notion) SupportsPropertyItems() bool {
+	_, ok := notion.api.(PropertyItemAPI)
+
+	return ok
+}
+
+// RetrievePageProperty retrieve complete value of property, following pages of list items.
+// Property is used for its ID and name, e.g. one of Page.Properties() whose value is truncated
+func (notion *Notion) RetrievePageProperty(PageID string, Property Property) (Property, error) {
+	if notion.invalid() {
+		return nil, fmt.Errorf("Nil pointer API Implementation")
+	}
+	if !notion.SupportsPropertyItems() {
+		return nil, notion.unsupported("page property items")
+	}
+	if Property == nil || len(Property.ID()) == 0 {
+		return nil, fmt.Errorf("ID of property is empty")
+	}
+
+	pagination := &PaginationRequest{PageSize: 100}
+	items := []JSON{}
+	var first *PropertyItem
+
+	for {
+		item, err := notion.RetrievePagePropertyItem(PageID, Property.ID(), pagination)
+		if err != nil {
+			return nil, err
+		}
+		if !item.IsList() {
+			return AssignProperty(Property.Name(), item.JSON)
+		}
+		if first == nil {
+			first = item
+		}
+
+		items = append(items, item.Results()...)
+		if !item.HasMore() || len(item.NextCursor()) == 0 {
+			break
+		}
+		pagination.StartCursor = item.NextCursor()
+	}
+
+	return AssignProperty(Property.Name(), assemblePropertyItems(Property.ID(), first, items))
+}
+
+// CompletePageProperties return properties of page, values which may be truncated in page object are retrieved completely.
+// it fails with ErrUnsupportedVersion unless SupportsPropertyItems
+func (notion *Notion) CompletePageProperties(Page *Page) ([]Property, error) {
+	if notion.invalid() {
+		return nil, fmt.Errorf("Nil pointer API Implementation")
+	}
+	if !notion.SupportsPropertyItems() {
+		return nil, notion.unsupported("page property items")
+	}
+
+	properties := Page.Properties()
+	for i, property := range properties {
+		switch property.Type() {
+		case TypePropertyTitle, TypePropertyRichText, TypePropertyRelation, TypePropertyPeople, TypePropertyRollup:
+		default:
+			continue
+		}
+
+		complete, err := notion.RetrievePageProperty(Page.ID(), property)
+		if err != nil {
+			return nil, fmt.Errorf("property '%s': %w", property.Name(), err)
+		}
+		properties[i] = complete
+	}
+
+	return properties, nil
+}
+
+// assemblePropertyItems make property value of page object from list items
+func assemblePropertyItems(ID string, first *PropertyItem, items []JSON) JSON {
+	t := first.Type()
+	j := JSON{
+		"id":   ID,
+		"type": t,
+	}
+
+	switch t {
+	case TypePropertyRollup:
+		rollup := JSON{}
+		if info, ok := first.JSON.GetJSON("property_item"); ok {
+			if jj, ok := info.GetJSON(TypePropertyRollup); ok {
+				rollup = jj
+			}
+		}
+		if rollup.GetString("type") == "array" || rollup.Get("type") == nil {
+			rollup["type"] = "array"
+
+			array := []interface{}{}
+			for _, item := range items {
+				delete(item, "object")
+				delete(item, "id")
+				array = append(array, item)
+			}
+			rollup["array"] = array
+		}
+		j[t] = rollup
+	default:
+		values := []interface{}{}
+		for _, item := range items {
+			if v := item.Get(item.GetString("type")); v != nil {
+				values = append(values, v)
+			}
+		}
+		j[t] = values
+	}
+
+	return j
+}
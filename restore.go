@@ -0,0 +1,612 @@
+package notion
+
+import (
+	"fmt"
+	"strings"
+)
+
+type RestoreOption struct {
+	//Users user ID of backup -> user ID of workspace. when nil, users are matched by ID and then by email with users of workspace
+	Users map[string]string
+	//Progress called after each page or database is created
+	Progress func(entry *RestoreEntry)
+}
+
+// RestoreEntry page or database of backup and its copy
+type RestoreEntry struct {
+	Object string `json:"object"`
+	OldID  string `json:"old_id"`
+	//NewID empty if object could not be restored
+	NewID string `json:"new_id"`
+	Title string `json:"title"`
+	//Warnings parts which could not be restored, e.g. people not in workspace
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+type RestoreResult struct {
+	Parent  string          `json:"parent"`
+	Entries []*RestoreEntry `json:"entries"`
+	//IDs old ID -> new ID of pages and databases
+	IDs map[string]string `json:"ids"`
+}
+
+type restorer struct {
+	notion *Notion
+	option RestoreOption
+	result *RestoreResult
+
+	//source latest archive which has file of object
+	source  map[string]*BackupArchive
+	entries map[string]BackupEntry
+	//children ID of parent -> entries
+	children map[string][]BackupEntry
+	restored map[string]*RestoreEntry
+	//deferred pages whose relations and mentions are written after all objects are created
+	deferred []BackupEntry
+	//synced database ID -> name of property, dual relation which Notion creates with its pair
+	synced map[string]map[string]bool
+	users  map[string]string
+}
+
+// Restore recreate pages, databases and their contents of backup under page of ParentPageID.
+// Archives are full backup followed by incremental backups, files of later archive replace earlier ones.
+// IDs of pages and databases in relations and mentions are remapped, IDs not in backup are kept.
+// databases are created first, then pages, then relations, rollups, formulas and mentions, then contents of pages,
+// so child pages are placed before other blocks. on error, result of objects restored so far is returned with error
+func (notion *Notion) Restore(Archives []*BackupArchive, ParentPageID string, Option *RestoreOption) (*RestoreResult, error) {
+	if notion.invalid() {
+		return nil, fmt.Errorf("Nil pointer API Implementation")
+	}
+	if len(Archives) == 0 {
+		return nil, fmt.Errorf("no backup archive")
+	}
+
+	r := &restorer{
+		notion:   notion,
+		result:   &RestoreResult{Parent: ParentPageID, Entries: []*RestoreEntry{}, IDs: map[string]string{}},
+		source:   map[string]*BackupArchive{},
+		entries:  map[string]BackupEntry{},
+		children: map[string][]BackupEntry{},
+		restored: map[string]*RestoreEntry{},
+		synced:   map[string]map[string]bool{},
+	}
+	if Option != nil {
+		r.option = *Option
+	}
+
+	for _, archive := range Archives {
+		for _, entry := range archive.Index.Entries {
+			if len(entry.Path) > 0 {
+				r.source[entry.ID] = archive
+			}
+		}
+	}
+	last := Archives[len(Archives)-1].Index.Entries
+	for _, entry := range last {
+		r.entries[entry.ID] = entry
+	}
+	for _, entry := range last {
+		parent := entry.ParentID
+		if _, ok := r.entries[parent]; !ok {
+			parent = ""
+		}
+		r.children[parent] = append(r.children[parent], entry)
+	}
+
+	if err := r.mapUsers(Archives[len(Archives)-1]); err != nil {
+		return r.result, err
+	}
+
+	steps := []func() error{
+		func() error { return r.create("", NewParentPage(ParentPageID)) },
+		func() error { return r.databaseProperties(true) },
+		func() error { return r.databaseProperties(false) },
+		r.pageProperties,
+		r.contents,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return r.result, err
+		}
+	}
+
+	return r.result, nil
+}
+
+func (r *restorer) mapUsers(archive *BackupArchive) error {
+	if r.option.Users != nil {
+		r.users = r.option.Users
+		return nil
+	}
+
+	r.users = map[string]string{}
+
+	backup, err := archive.Users()
+	if err != nil {
+		return err
+	}
+	list, err := listAll(r.notion.ListAllUsers)
+	if err != nil {
+		return fmt.Errorf("users: %w", err)
+	}
+
+	ids := map[string]bool{}
+	emails := map[string]string{}
+	for _, j := range list {
+		ids[j.GetString("id")] = true
+		if person, ok := j.GetJSON("person"); ok && len(person.GetString("email")) > 0 {
+			emails[strings.ToLower(person.GetString("email"))] = j.GetString("id")
+		}
+	}
+
+	for _, user := range backup {
+		if ids[user.ID] {
+			r.users[user.ID] = user.ID
+			continue
+		}
+		if person, ok := user.JSON.GetJSON("person"); ok {
+			if id, ok := emails[strings.ToLower(person.GetString("email"))]; ok {
+				r.users[user.ID] = id
+			}
+		}
+	}
+
+	return nil
+}
+
+// create make pages and databases under parent, parent is "" for objects whose parent is not in backup
+func (r *restorer) create(ParentID string, parent *Parent) error {
+	for _, entry := range r.children[ParentID] {
+		restored := &RestoreEntry{Object: entry.Object, OldID: entry.ID, Title: entry.Title}
+		r.result.Entries = append(r.result.Entries, restored)
+		r.restored[entry.ID] = restored
+
+		var child *Parent
+		var err error
+		switch {
+		case r.source[entry.ID] == nil:
+			restored.warn("%s is not in backup", entry.Object)
+		case entry.Object == ObjectDatabase.String():
+			if parent.JSON.GetString("type") == TypeParentDatabase {
+				restored.warn("database in database can not be created")
+				break
+			}
+			child, err = r.createDatabase(entry, restored, parent)
+		case entry.Object == ObjectPage.String():
+			child, err = r.createPage(entry, restored, parent)
+		}
+		if err != nil {
+			return fmt.Errorf("%s '%s': %w", entry.Object, entry.ID, err)
+		}
+
+		if child == nil {
+			// children of object which is not restored are placed in parent of restore
+			child = NewParentPage(r.result.Parent)
+		} else {
+			restored.NewID = child.ID
+			r.result.IDs[entry.ID] = child.ID
+			if r.option.Progress != nil {
+				r.option.Progress(restored)
+			}
+		}
+
+		if err := r.create(entry.ID, child); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *restorer) createDatabase(entry BackupEntry, restored *RestoreEntry, parent *Parent) (*Parent, error) {
+	database, err := r.source[entry.ID].Database(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	// relations, rollups and formulas are added after all databases are created
+	properties := []Configuration{}
+	for _, configuration := range database.Properties() {
+		switch configuration.Type() {
+		case TypePropertyRelation, TypePropertyRollup, TypePropertyFormula:
+			continue
+		}
+		properties = append(properties, restoreConfiguration(configuration, ""))
+	}
+
+	title, _ := r.richText(database.Title(), restored, false)
+	created, err := r.notion.CreateDatabase(parent, title, properties)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewParentDatabase(created.ID()), nil
+}
+
+// databaseProperties add relations of databases, or rollups and formulas which may refer relations
+func (r *restorer) databaseProperties(relations bool) error {
+	for _, restored := range r.result.Entries {
+		if restored.Object != ObjectDatabase.String() || len(restored.NewID) == 0 {
+			continue
+		}
+
+		entry := r.entries[restored.OldID]
+		database, err := r.source[entry.ID].Database(entry)
+		if err != nil {
+			return err
+		}
+
+		properties := []Configuration{}
+		for _, configuration := range database.Properties() {
+			switch configuration.Type() {
+			case TypePropertyRelation:
+				if !relations || r.synced[entry.ID][configuration.Name()] {
+					continue
+				}
+
+				relation := configuration.(*ConfigurationRelation)
+				target := relation.DatabaseID()
+				if len(relation.SyncedPropertyName()) > 0 {
+					// other side of dual relation is created by Notion
+					if r.synced[target] == nil {
+						r.synced[target] = map[string]bool{}
+					}
+					r.synced[target][relation.SyncedPropertyName()] = true
+				}
+				if id, ok := r.result.IDs[target]; ok {
+					target = id
+				}
+				properties = append(properties, restoreConfiguration(configuration, target))
+			case TypePropertyRollup, TypePropertyFormula:
+				if relations {
+					continue
+				}
+				properties = append(properties, restoreConfiguration(configuration, ""))
+			}
+		}
+		if len(properties) == 0 {
+			continue
+		}
+
+		if _, err := r.notion.UpdateDatabase(restored.NewID, nil, properties); err != nil {
+			return fmt.Errorf("database '%s': %w", entry.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// restoreConfiguration copy configuration without IDs of backup, DatabaseID is new target of relation
+func restoreConfiguration(configuration Configuration, DatabaseID string) Configuration {
+	t := configuration.Type()
+
+	v := JSON{}
+	if j, ok := configuration.Json().GetJSON(t); ok {
+		v.Marshal(j)
+	}
+
+	switch t {
+	case TypePropertySelect, TypePropertyMultiSelect:
+		options, _ := v.GetJSONList("options")
+		for _, option := range options {
+			delete(option, "id")
+		}
+		if options != nil {
+			v["options"] = options
+		}
+	case TypePropertyRelation:
+		v = JSON{"database_id": DatabaseID}
+	case TypePropertyRollup:
+		delete(v, "relation_property_id")
+		delete(v, "rollup_property_id")
+	}
+
+	restored, _ := AssignConfiguration(configuration.Name(), JSON{"type": t, t: v})
+
+	return restored
+}
+
+func (r *restorer) createPage(entry BackupEntry, restored *RestoreEntry, parent *Parent) (*Parent, error) {
+	page, err := r.source[entry.ID].Page(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	properties, deferred := r.properties(&Page{JSON: page.Page}, restored, parent, false)
+	created, err := r.notion.CreatePage(parent, properties)
+	if err != nil {
+		return nil, err
+	}
+	if deferred {
+		r.deferred = append(r.deferred, entry)
+	}
+
+	return NewParentPage(created.ID()), nil
+}
+
+// pageProperties write relations and mentions of pages after all pages are created
+func (r *restorer) pageProperties() error {
+	for _, entry := range r.deferred {
+		restored := r.restored[entry.ID]
+
+		page, err := r.source[entry.ID].Page(entry)
+		if err != nil {
+			return err
+		}
+
+		parent := NewParentPage(r.result.Parent)
+		if id, ok := r.result.IDs[entry.ParentID]; ok {
+			if entry.ParentType == TypeParentDatabase {
+				parent = NewParentDatabase(id)
+			} else {
+				parent = NewParentPage(id)
+			}
+		}
+
+		properties, _ := r.properties(&Page{JSON: page.Page}, restored, parent, true)
+		if len(properties) == 0 {
+			continue
+		}
+		if _, err := r.notion.UpdatePageProperties(restored.NewID, properties...); err != nil {
+			return fmt.Errorf("page '%s': %w", entry.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// properties values of page to write in parent. before all pages are created, relations are skipped and
+// mentions of pages are written as text, deferred reports there are such values
+func (r *restorer) properties(page *Page, restored *RestoreEntry, parent *Parent, created bool) ([]Property, bool) {
+	inDatabase := parent.JSON.GetString("type") == TypeParentDatabase
+	database := ""
+	if p := page.Parent(); p != nil {
+		database = p.ID
+	}
+
+	properties := []Property{}
+	deferred := false
+	for _, property := range page.Properties() {
+		t := property.Type()
+		name := property.Name()
+
+		if t == TypePropertyTitle && !inDatabase {
+			name = "title"
+		} else if !inDatabase || IsReadOnlyProperty(property) || r.synced[database][name] {
+			continue
+		}
+
+		v := JSON{}
+		v.Marshal(property.Json())
+		delete(v, "id")
+
+		switch t {
+		case TypePropertyTitle, TypePropertyRichText:
+			text, later := r.richText(property.(interface{ RichText() []RichText }).RichText(), restored, created)
+			deferred = deferred || later
+			list := []JSON{}
+			for _, rt := range text {
+				list = append(list, rt.JSON)
+			}
+			v[t] = list
+		case TypePropertyRelation:
+			if !created {
+				deferred = true
+				continue
+			}
+			relations, _ := v.GetJSONList(t)
+			list := []JSON{}
+			for _, relation := range relations {
+				list = append(list, JSON{"id": r.pageID(relation.GetString("id"))})
+			}
+			v[t] = list
+		case TypePropertySelect:
+			if option, ok := v.GetJSON(t); ok {
+				delete(option, "id")
+				v[t] = option
+			}
+		case TypePropertyMultiSelect:
+			options, _ := v.GetJSONList(t)
+			for _, option := range options {
+				delete(option, "id")
+			}
+			if options != nil {
+				v[t] = options
+			}
+		case TypePropertyPeople:
+			people, _ := v.GetJSONList(t)
+			list := []JSON{}
+			for _, person := range people {
+				if id, ok := r.users[person.GetString("id")]; ok {
+					list = append(list, JSON{"object": "user", "id": id})
+				} else if !created {
+					restored.warn("person '%s' of '%s' is not in workspace", person.GetString("name"), name)
+				}
+			}
+			v[t] = list
+		case TypePropertyFiles:
+			files, _ := v.GetJSONList(t)
+			for _, file := range files {
+				if f, ok := file.GetJSON("file"); ok {
+					delete(file, "file")
+					file["type"] = "external"
+					file["external"] = JSON{"url": f.GetString("url")}
+				}
+			}
+			if files != nil {
+				v[t] = files
+			}
+		}
+		if created && t != TypePropertyRelation && !hasMention(v) {
+			continue
+		}
+
+		p, err := AssignProperty(name, v)
+		if err != nil {
+			continue
+		}
+		properties = append(properties, p)
+	}
+
+	return properties, deferred
+}
+
+func hasMention(j JSON) bool {
+	return strings.Contains(j.String(), `"mention"`)
+}
+
+// pageID new ID of page or database, ID not in backup is kept
+func (r *restorer) pageID(ID string) string {
+	if id, ok := r.result.IDs[ID]; ok {
+		return id
+	}
+	for old, id := range r.result.IDs {
+		if strings.ReplaceAll(old, "-", "") == strings.ReplaceAll(ID, "-", "") {
+			return id
+		}
+	}
+
+	return ID
+}
+
+// richText copy text with mentions of new IDs. before all objects are created, mentions of objects in backup
+// are written as text and deferred is true
+func (r *restorer) richText(text []RichText, restored *RestoreEntry, created bool) ([]RichText, bool) {
+	list := []RichText{}
+	deferred := false
+
+	for _, rt := range text {
+		j := JSON{}
+		j.Marshal(rt.JSON)
+
+		var id string
+		switch rt.MentionType() {
+		case MentionTypePage, MentionTypeDatabase:
+			mention, _ := j.GetJSON("mention")
+			target, _ := mention.GetJSON(mention.GetString("type"))
+			id = target.GetString("id")
+			if _, ok := r.entries[id]; ok && !created {
+				deferred = true
+				j = plainTextJSON(rt)
+				break
+			}
+			mention[mention.GetString("type")] = JSON{"id": r.pageID(id)}
+			j["mention"] = mention
+		case MentionTypeUser:
+			mention, _ := j.GetJSON("mention")
+			user, _ := mention.GetJSON(MentionTypeUser)
+			if id, ok := r.users[user.GetString("id")]; ok {
+				mention[MentionTypeUser] = JSON{"object": "user", "id": id}
+				j["mention"] = mention
+				break
+			}
+			if !created {
+				restored.warn("mention of user '%s' is not in workspace", rt.PlainText())
+			}
+			j = plainTextJSON(rt)
+		}
+
+		list = append(list, RichText{JSON: j})
+	}
+
+	return list, deferred
+}
+
+// plainTextJSON text of rich text keeping annotations and link
+func plainTextJSON(rt RichText) JSON {
+	j := JSON{
+		"type": "text",
+		"text": JSON{"content": rt.PlainText()},
+	}
+	if annotations, ok := rt.JSON.GetJSON("annotations"); ok {
+		j["annotations"] = annotations
+	}
+	if len(rt.Href()) > 0 {
+		j["text"] = JSON{"content": rt.PlainText(), "link": JSON{"url": rt.Href()}}
+	}
+
+	return j
+}
+
+// contents append blocks of restored pages, child pages and databases are already created
+func (r *restorer) contents() error {
+	for _, restored := range r.result.Entries {
+		if restored.Object != ObjectPage.String() || len(restored.NewID) == 0 {
+			continue
+		}
+
+		entry := r.entries[restored.OldID]
+		page, err := r.source[entry.ID].Page(entry)
+		if err != nil {
+			return err
+		}
+
+		blocks := TreeBlocks(r.nodes(page.Blocks, restored))
+		if len(blocks) == 0 {
+			continue
+		}
+		if _, err := r.notion.AppendBlockTree(restored.NewID, blocks); err != nil {
+			return fmt.Errorf("page '%s': %w", entry.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// nodes blocks of backup which can be appended, mentions are remapped and uploaded images are linked
+func (r *restorer) nodes(list []JSON, restored *RestoreEntry) []*BlockNode {
+	nodes := []*BlockNode{}
+
+	for _, j := range list {
+		t := j.GetString("type")
+		switch t {
+		case TypeBlockChildPage, TypeBlockChildDatabase:
+			continue
+		case TypeBlockUnsupported:
+			restored.warn("unsupported block '%s' is skipped", j.GetString("id"))
+			continue
+		}
+
+		children, _ := j.GetJSONList("children")
+		delete(j, "children")
+
+		if v, ok := j.GetJSON(t); ok {
+			for _, key := range []string{"text", "rich_text", "caption"} {
+				items, ok := v.GetJSONList(key)
+				if !ok {
+					continue
+				}
+				text := []RichText{}
+				for _, item := range items {
+					text = append(text, RichText{JSON: item})
+				}
+				text, _ = r.richText(text, restored, true)
+				items = []JSON{}
+				for _, rt := range text {
+					items = append(items, rt.JSON)
+				}
+				v[key] = items
+			}
+			if f, ok := v.GetJSON("file"); ok && v.GetString("type") == "file" {
+				// URL of uploaded file expires
+				delete(v, "file")
+				v["type"] = "external"
+				v["external"] = JSON{"url": f.GetString("url")}
+				restored.warn("uploaded file of block '%s' is linked by URL which expires", j.GetString("id"))
+			}
+			j[t] = v
+		}
+
+		block, err := AssignBlock(j)
+		if err != nil {
+			block = NewBlock(j)
+		}
+		nodes = append(nodes, &BlockNode{Block: block, Children: r.nodes(children, restored)})
+	}
+
+	return nodes
+}
+
+func (entry *RestoreEntry) warn(format string, a ...interface{}) {
+	entry.Warnings = append(entry.Warnings, fmt.Sprintf(format, a...))
+}
@@ -0,0 +1,202 @@
+package notion
+
+import (
+	"regexp"
+	"unicode/utf8"
+)
+
+// MaxRichTextLength maximum length of content of one text object
+const MaxRichTextLength = 2000
+
+var userIDPattern = regexp.MustCompile(`^(?i:[0-9a-f]{8}-?[0-9a-f]{4}-?[0-9a-f]{4}-?[0-9a-f]{4}-?[0-9a-f]{12})`)
+
+// RichTextBuilder build list of rich text run by run
+//
+//	text := notion.NewRichTextBuilder().Bold("x").Italic("y").Link("z", url).MentionUser(id).Build()
+type RichTextBuilder struct {
+	text []RichText
+}
+
+func NewRichTextBuilder() *RichTextBuilder {
+	return &RichTextBuilder{
+		text: []RichText{},
+	}
+}
+
+// Styled append text with annotations
+func (builder *RichTextBuilder) Styled(Content string, Annotations *Annotations) *RichTextBuilder {
+	if Annotations == nil {
+		Annotations = NewAnnotations()
+	}
+	builder.text = append(builder.text, newRichTextRun(Content, *Annotations, ""))
+
+	return builder
+}
+
+func (builder *RichTextBuilder) Text(Content string) *RichTextBuilder {
+	return builder.Styled(Content, nil)
+}
+
+func (builder *RichTextBuilder) Bold(Content string) *RichTextBuilder {
+	annotations := NewAnnotations()
+	annotations.Bold = true
+
+	return builder.Styled(Content, annotations)
+}
+
+func (builder *RichTextBuilder) Italic(Content string) *RichTextBuilder {
+	annotations := NewAnnotations()
+	annotations.Italic = true
+
+	return builder.Styled(Content, annotations)
+}
+
+func (builder *RichTextBuilder) Strikethrough(Content string) *RichTextBuilder {
+	annotations := NewAnnotations()
+	annotations.Strikethrough = true
+
+	return builder.Styled(Content, annotations)
+}
+
+func (builder *RichTextBuilder) Underline(Content string) *RichTextBuilder {
+	annotations := NewAnnotations()
+	annotations.Underline = true
+
+	return builder.Styled(Content, annotations)
+}
+
+func (builder *RichTextBuilder) Code(Content string) *RichTextBuilder {
+	annotations := NewAnnotations()
+	annotations.Code = true
+
+	return builder.Styled(Content, annotations)
+}
+
+func (builder *RichTextBuilder) Color(Content string, Color Color) *RichTextBuilder {
+	annotations := NewAnnotations()
+	annotations.Color = string(Color)
+
+	return builder.Styled(Content, annotations)
+}
+
+func (builder *RichTextBuilder) Link(Content string, URL string) *RichTextBuilder {
+	builder.text = append(builder.text, newRichTextRun(Content, *NewAnnotations(), URL))
+
+	return builder
+}
+
+func (builder *RichTextBuilder) Equation(Expression string) *RichTextBuilder {
+	rt := NewRichText(Expression)
+	rt.SetEquation(&Equation{Expression: Expression})
+	rt.SetAnnotations(NewAnnotations())
+	builder.text = append(builder.text, *rt)
+
+	return builder
+}
+
+func (builder *RichTextBuilder) MentionUser(UserID string) *RichTextBuilder {
+	rt := NewRichText("@" + UserID)
+	rt.SetMentionUser(NewUser(UserID))
+	rt.SetAnnotations(NewAnnotations())
+	builder.text = append(builder.text, *rt)
+
+	return builder
+}
+
+func (builder *RichTextBuilder) MentionPage(PageID string) *RichTextBuilder {
+	rt := NewRichText(PageID)
+	rt.SetMentionPage(&Page{JSON: JSON{"id": PageID}})
+	rt.SetAnnotations(NewAnnotations())
+	builder.text = append(builder.text, *rt)
+
+	return builder
+}
+
+func (builder *RichTextBuilder) MentionDatabase(DatabaseID string) *RichTextBuilder {
+	rt := NewRichText(DatabaseID)
+	rt.SetMentionDatabase(&Database{JSON: JSON{"id": DatabaseID}})
+	rt.SetAnnotations(NewAnnotations())
+	builder.text = append(builder.text, *rt)
+
+	return builder
+}
+
+func (builder *RichTextBuilder) MentionDate(Date *Date) *RichTextBuilder {
+	rt := NewRichText(dateText(Date))
+	rt.SetMentionDate(Date)
+	rt.SetAnnotations(NewAnnotations())
+	builder.text = append(builder.text, *rt)
+
+	return builder
+}
+
+// Append append rich text as it is
+func (builder *RichTextBuilder) Append(Text ...RichText) *RichTextBuilder {
+	builder.text = append(builder.text, Text...)
+
+	return builder
+}
+
+// Markup append text parsed by ParseRichText
+func (builder *RichTextBuilder) Markup(Markup string, Users map[string]string) *RichTextBuilder {
+	builder.text = append(builder.text, ParseRichText(Markup, Users)...)
+
+	return builder
+}
+
+// Build return rich text, text longer than MaxRichTextLength is split
+func (builder *RichTextBuilder) Build() []RichText {
+	return SplitRichText(builder.text)
+}
+
+// ParseRichText convert lightweight inline markup into rich text:
+// **bold**, _italic_, ~~strikethrough~~, `code`, [link](url), $equation$ and @user.
+// @user is ID of user, or name which is found in Users (name -> ID). unknown names are left as text
+func ParseRichText(Markup string, Users map[string]string) []RichText {
+	parser := &markdownInline{
+		runs:        []RichText{},
+		annotations: *NewAnnotations(),
+		mention:     true,
+		users:       Users,
+	}
+
+	parser.parse(Markup)
+	parser.flush()
+
+	return SplitRichText(parser.runs)
+}
+
+// SplitRichText split text runs longer than MaxRichTextLength into several runs with same annotations and link
+func SplitRichText(text []RichText) []RichText {
+	list := make([]RichText, 0, len(text))
+
+	for i := range text {
+		rt := text[i]
+
+		if rt.Type() != "text" {
+			list = append(list, rt)
+			continue
+		}
+
+		t, err := rt.GetText()
+		if err != nil || utf8.RuneCountInString(t.Content) <= MaxRichTextLength {
+			list = append(list, rt)
+			continue
+		}
+
+		annotations := annotationsOf(&rt)
+		url := linkOf(t)
+
+		runes := []rune(t.Content)
+		for start := 0; start < len(runes); start += MaxRichTextLength {
+			end := start + MaxRichTextLength
+			if end > len(runes) {
+				end = len(runes)
+			}
+
+			list = append(list, newRichTextRun(string(runes[start:end]), *annotations, url))
+		}
+	}
+
+	return list
+}
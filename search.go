@@ -1,5 +1,10 @@
 package notion
 
+import (
+	"encoding/json"
+	"time"
+)
+
 type FilterOperation string
 
 const (
@@ -126,8 +131,11 @@ func (c *Conditioner) Value() interface{} {
 
 type ConditionFuncString func(value string) Condition
 type ConditionFuncNumber func(value int) Condition
+type ConditionFuncFloat func(value float64) Condition
+type ConditionFuncDecimal func(value json.Number) Condition
 type ConditionFuncBoolean func(value bool) Condition
 type ConditionFuncObject func(value interface{}) Condition
+type ConditionFuncTime func(value time.Time) Condition
 
 type ConditionText struct {
 	Equals         ConditionFuncString
@@ -173,6 +181,52 @@ var FilterNumber = &ConditionNumber{
 	IsNotEmpty:           func(value bool) Condition { return NewCondition("number", "is_not_empty", true) },
 }
 
+type ConditionNumberFloat struct {
+	Equals               ConditionFuncFloat
+	DoesNotEqual         ConditionFuncFloat
+	GreaterThan          ConditionFuncFloat
+	LessThan             ConditionFuncFloat
+	GreaterThanOrEqualTo ConditionFuncFloat
+	LessThanOrEqualTo    ConditionFuncFloat
+	IsEmpty              ConditionFuncBoolean
+	IsNotEmpty           ConditionFuncBoolean
+}
+
+// FilterNumberFloat same as FilterNumber with decimal value
+var FilterNumberFloat = &ConditionNumberFloat{
+	Equals:               func(value float64) Condition { return NewCondition("number", "equals", value) },
+	DoesNotEqual:         func(value float64) Condition { return NewCondition("number", "does_not_equal", value) },
+	GreaterThan:          func(value float64) Condition { return NewCondition("number", "greater_than", value) },
+	LessThan:             func(value float64) Condition { return NewCondition("number", "less_than", value) },
+	GreaterThanOrEqualTo: func(value float64) Condition { return NewCondition("number", "greater_than_or_equal_to", value) },
+	LessThanOrEqualTo:    func(value float64) Condition { return NewCondition("number", "less_than_or_equal_to", value) },
+	IsEmpty:              func(value bool) Condition { return NewCondition("number", "is_empty", true) },
+	IsNotEmpty:           func(value bool) Condition { return NewCondition("number", "is_not_empty", true) },
+}
+
+type ConditionNumberDecimal struct {
+	Equals               ConditionFuncDecimal
+	DoesNotEqual         ConditionFuncDecimal
+	GreaterThan          ConditionFuncDecimal
+	LessThan             ConditionFuncDecimal
+	GreaterThanOrEqualTo ConditionFuncDecimal
+	LessThanOrEqualTo    ConditionFuncDecimal
+	IsEmpty              ConditionFuncBoolean
+	IsNotEmpty           ConditionFuncBoolean
+}
+
+// FilterNumberDecimal same as FilterNumber with decimal string, e.g. json.Number("0.1"), which is sent as written
+var FilterNumberDecimal = &ConditionNumberDecimal{
+	Equals:               func(value json.Number) Condition { return NewCondition("number", "equals", value) },
+	DoesNotEqual:         func(value json.Number) Condition { return NewCondition("number", "does_not_equal", value) },
+	GreaterThan:          func(value json.Number) Condition { return NewCondition("number", "greater_than", value) },
+	LessThan:             func(value json.Number) Condition { return NewCondition("number", "less_than", value) },
+	GreaterThanOrEqualTo: func(value json.Number) Condition { return NewCondition("number", "greater_than_or_equal_to", value) },
+	LessThanOrEqualTo:    func(value json.Number) Condition { return NewCondition("number", "less_than_or_equal_to", value) },
+	IsEmpty:              func(value bool) Condition { return NewCondition("number", "is_empty", true) },
+	IsNotEmpty:           func(value bool) Condition { return NewCondition("number", "is_not_empty", true) },
+}
+
 type ConditionCheckbox struct {
 	Equals       ConditionFuncBoolean
 	DoesNotEqual ConditionFuncBoolean
@@ -225,24 +279,47 @@ type ConditionDate struct {
 	NextWeek   ConditionFuncObject
 	NextMonth  ConditionFuncObject
 	NextYear   ConditionFuncObject
-}
 
-var FilterDate = &ConditionDate{
-	Equals:     func(value string) Condition { return NewCondition("date", "equals", value) },
-	Before:     func(value string) Condition { return NewCondition("date", "before", value) },
-	After:      func(value string) Condition { return NewCondition("date", "after", value) },
-	OnOrBefore: func(value string) Condition { return NewCondition("date", "on_or_before", value) },
-	IsEmpty:    func(value bool) Condition { return NewCondition("date", "is_empty", true) },
-	IsNotEmpty: func(value bool) Condition { return NewCondition("date", "is_not_empty", true) },
-	OnOrAfter:  func(value string) Condition { return NewCondition("date", "on_or_after", value) },
-	PastWeek:   func(value interface{}) Condition { return NewCondition("date", "past_week", JSON{}) },
-	PastMonth:  func(value interface{}) Condition { return NewCondition("date", "past_month", JSON{}) },
-	PastYear:   func(value interface{}) Condition { return NewCondition("date", "past_year", JSON{}) },
-	NextWeek:   func(value interface{}) Condition { return NewCondition("date", "next_week", JSON{}) },
-	NextMonth:  func(value interface{}) Condition { return NewCondition("date", "next_month", JSON{}) },
-	NextYear:   func(value interface{}) Condition { return NewCondition("date", "next_year", JSON{}) },
+	//EqualsTime ... compare with time.Time, value is written with offset of its location
+	EqualsTime     ConditionFuncTime
+	BeforeTime     ConditionFuncTime
+	AfterTime      ConditionFuncTime
+	OnOrBeforeTime ConditionFuncTime
+	OnOrAfterTime  ConditionFuncTime
+}
+
+func newConditionDate(Type string) *ConditionDate {
+	return &ConditionDate{
+		Equals:     func(value string) Condition { return NewCondition(Type, "equals", value) },
+		Before:     func(value string) Condition { return NewCondition(Type, "before", value) },
+		After:      func(value string) Condition { return NewCondition(Type, "after", value) },
+		OnOrBefore: func(value string) Condition { return NewCondition(Type, "on_or_before", value) },
+		IsEmpty:    func(value bool) Condition { return NewCondition(Type, "is_empty", true) },
+		IsNotEmpty: func(value bool) Condition { return NewCondition(Type, "is_not_empty", true) },
+		OnOrAfter:  func(value string) Condition { return NewCondition(Type, "on_or_after", value) },
+		PastWeek:   func(value interface{}) Condition { return NewCondition(Type, "past_week", JSON{}) },
+		PastMonth:  func(value interface{}) Condition { return NewCondition(Type, "past_month", JSON{}) },
+		PastYear:   func(value interface{}) Condition { return NewCondition(Type, "past_year", JSON{}) },
+		NextWeek:   func(value interface{}) Condition { return NewCondition(Type, "next_week", JSON{}) },
+		NextMonth:  func(value interface{}) Condition { return NewCondition(Type, "next_month", JSON{}) },
+		NextYear:   func(value interface{}) Condition { return NewCondition(Type, "next_year", JSON{}) },
+
+		EqualsTime:     func(value time.Time) Condition { return NewCondition(Type, "equals", FormatDateTime(value)) },
+		BeforeTime:     func(value time.Time) Condition { return NewCondition(Type, "before", FormatDateTime(value)) },
+		AfterTime:      func(value time.Time) Condition { return NewCondition(Type, "after", FormatDateTime(value)) },
+		OnOrBeforeTime: func(value time.Time) Condition { return NewCondition(Type, "on_or_before", FormatDateTime(value)) },
+		OnOrAfterTime:  func(value time.Time) Condition { return NewCondition(Type, "on_or_after", FormatDateTime(value)) },
+	}
 }
 
+var FilterDate = newConditionDate("date")
+
+// FilterCreatedTime condition of created_time property
+var FilterCreatedTime = newConditionDate("created_time")
+
+// FilterLastEditedTime condition of last_edited_time property
+var FilterLastEditedTime = newConditionDate("last_edited_time")
+
 type ConditionPeople struct {
 	Contains       ConditionFuncString
 	DoesNotContain ConditionFuncString
@@ -250,13 +327,23 @@ type ConditionPeople struct {
 	IsNotEmpty     ConditionFuncBoolean
 }
 
-var FilterPeople = &ConditionPeople{
-	Contains:       func(value string) Condition { return NewCondition("people", "contains", value) },
-	DoesNotContain: func(value string) Condition { return NewCondition("people", "does_not_contain", value) },
-	IsEmpty:        func(value bool) Condition { return NewCondition("people", "is_empty", true) },
-	IsNotEmpty:     func(value bool) Condition { return NewCondition("people", "is_not_empty", true) },
+func newConditionPeople(Type string) *ConditionPeople {
+	return &ConditionPeople{
+		Contains:       func(value string) Condition { return NewCondition(Type, "contains", value) },
+		DoesNotContain: func(value string) Condition { return NewCondition(Type, "does_not_contain", value) },
+		IsEmpty:        func(value bool) Condition { return NewCondition(Type, "is_empty", true) },
+		IsNotEmpty:     func(value bool) Condition { return NewCondition(Type, "is_not_empty", true) },
+	}
 }
 
+var FilterPeople = newConditionPeople("people")
+
+// FilterCreatedBy condition of created_by property
+var FilterCreatedBy = newConditionPeople("created_by")
+
+// FilterLastEditedBy condition of last_edited_by property
+var FilterLastEditedBy = newConditionPeople("last_edited_by")
+
 type ConditionFiles struct {
 	IsEmpty    ConditionFuncBoolean
 	IsNotEmpty ConditionFuncBoolean
@@ -267,6 +354,52 @@ var FilterFiles = &ConditionFiles{
 	IsNotEmpty: func(value bool) Condition { return NewCondition("files", "is_not_empty", true) },
 }
 
+type ConditionRelation struct {
+	Contains       ConditionFuncString
+	DoesNotContain ConditionFuncString
+	IsEmpty        ConditionFuncBoolean
+	IsNotEmpty     ConditionFuncBoolean
+}
+
+var FilterRelation = &ConditionRelation{
+	Contains:       func(value string) Condition { return NewCondition("relation", "contains", value) },
+	DoesNotContain: func(value string) Condition { return NewCondition("relation", "does_not_contain", value) },
+	IsEmpty:        func(value bool) Condition { return NewCondition("relation", "is_empty", true) },
+	IsNotEmpty:     func(value bool) Condition { return NewCondition("relation", "is_not_empty", true) },
+}
+
+type ConditionFuncCondition func(value Condition) Condition
+
+// ConditionRollup wrap condition of rolled up values,
+// e.g. FilterRollup.Any(FilterText.Contains("x")) or FilterRollup.Number(FilterNumber.GreaterThan(5))
+type ConditionRollup struct {
+	Any    ConditionFuncCondition
+	Every  ConditionFuncCondition
+	None   ConditionFuncCondition
+	Number ConditionFuncCondition
+	Date   ConditionFuncCondition
+}
+
+func rollupArrayCondition(key string) ConditionFuncCondition {
+	return func(value Condition) Condition {
+		return NewCondition("rollup", key, JSON{value.Type(): JSON{value.Key(): value.Value()}})
+	}
+}
+
+func rollupValueCondition(key string) ConditionFuncCondition {
+	return func(value Condition) Condition {
+		return NewCondition("rollup", key, JSON{value.Key(): value.Value()})
+	}
+}
+
+var FilterRollup = &ConditionRollup{
+	Any:    rollupArrayCondition("any"),
+	Every:  rollupArrayCondition("every"),
+	None:   rollupArrayCondition("none"),
+	Number: rollupValueCondition("number"),
+	Date:   rollupValueCondition("date"),
+}
+
 type ConditionFormula struct {
 	Text     *ConditionText
 	Checkbox *ConditionCheckbox
@@ -0,0 +1,282 @@
+package notion
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TextChunk plain text of consecutive blocks in one section
+type TextChunk struct {
+	//Section titles of headings which contain the chunk, outermost first
+	Section []string
+	//BlockIDs blocks of chunk in document order, ID of page for properties chunk
+	BlockIDs []string
+	Text     string
+}
+
+type ExtractOption struct {
+	//MaxChunkSize split chunk at block boundary when length of text exceeds, 0 is unlimited
+	MaxChunkSize int
+	//SkipProperties do not make chunk from properties of page
+	SkipProperties bool
+}
+
+// PlainText concatenate plain text of rich text
+func PlainText(text []RichText) string {
+	b := &strings.Builder{}
+
+	for i := range text {
+		if text[i].Type() == "equation" {
+			if equation, err := text[i].GetEquation(); err == nil {
+				b.WriteString(equation.Expression)
+				continue
+			}
+		}
+		b.WriteString(text[i].PlainText())
+	}
+
+	return b.String()
+}
+
+// PropertyPlainText return value of property as plain text
+func PropertyPlainText(property Property) string {
+	switch p := property.Interface().(type) {
+	case *PropertyTitle:
+		return PlainText(p.RichText())
+	case *PropertyRichText:
+		return PlainText(p.RichText())
+	case *RichTextProperty:
+		return PlainText(p.RichText())
+	case *PropertyNumber:
+		return string(p.Decimal())
+	case *PropertySelect:
+		if option := p.Option(); option != nil {
+			return option.Name
+		}
+	case *PropertyMultiSelect:
+		names := []string{}
+		for _, option := range p.Options() {
+			names = append(names, option.Name)
+		}
+		return strings.Join(names, ", ")
+	case *PropertyDate:
+		return dateText(p.Date())
+	case *PropertyFormula:
+		t, v := p.Formula()
+		switch t {
+		case "":
+			return ""
+		case "number":
+			return string(p.Decimal())
+		case "date":
+			date, _ := v.(*Date)
+			return dateText(date)
+		}
+		return fmt.Sprint(v)
+	case *PropertyPeople:
+		names := []string{}
+		for _, user := range p.Users() {
+			names = append(names, user.Name())
+		}
+		return strings.Join(names, ", ")
+	case *PropertyFiles:
+		names := []string{}
+		for _, file := range p.Files() {
+			names = append(names, file.Name)
+		}
+		return strings.Join(names, ", ")
+	case *PropertyCheckbox:
+		return fmt.Sprint(p.Checked())
+	case *PropertyURL:
+		return p.URL()
+	case *PropertyEmail:
+		return p.Email()
+	case *PropertyPhoneNumber:
+		return p.PhoneNumber()
+	case *PropertyCreatedTime:
+		return p.JSON.GetString(p.Type())
+	case *PropertyLastEditedTime:
+		return p.JSON.GetString(p.Type())
+	case *PropertyCreatedBy:
+		if user := p.User(); user != nil {
+			return user.Name()
+		}
+	case *PropertyLastEditedBy:
+		if user := p.User(); user != nil {
+			return user.Name()
+		}
+	case *PropertyRelation:
+		return strings.Join(p.PageIDs(), ", ")
+	case *PropertyRollup:
+		switch p.RollupType() {
+		case "number":
+			return p.rollup().GetString("number")
+		case "date":
+			return dateText(p.Date())
+		case "array":
+			values := []string{}
+			for _, v := range p.Array() {
+				if text := PropertyPlainText(v); len(text) > 0 {
+					values = append(values, text)
+				}
+			}
+			return strings.Join(values, ", ")
+		}
+	}
+
+	return ""
+}
+
+func dateText(date *Date) string {
+	if date == nil || len(date.Start) == 0 {
+		return ""
+	}
+	if len(date.End) == 0 {
+		return date.Start
+	}
+
+	return date.Start + " → " + date.End
+}
+
+// BlockPlainText return text of block without children
+func BlockPlainText(block Block) string {
+	if rtb, ok := richTextBlock(block); ok {
+		list, _ := rtb.ListText()
+		return PlainText(list)
+	}
+
+	if block.Type() == TypeBlockChildPage {
+		if j, ok := block.Json().GetJSON(TypeBlockChildPage); ok {
+			return j.GetString("title")
+		}
+	}
+
+	return ""
+}
+
+type textExtractor struct {
+	option ExtractOption
+
+	chunks  []TextChunk
+	section []string
+	current *TextChunk
+	//heading current chunk starts with heading, it is kept without text
+	heading bool
+}
+
+// ExtractText flatten properties of page and block tree into plain text chunks.
+// each heading starts new chunk, so chunks are bounded by sections. chunk of heading without content has empty Text
+func ExtractText(page *Page, nodes []*BlockNode, option *ExtractOption) []TextChunk {
+	extractor := &textExtractor{
+		chunks:  []TextChunk{},
+		section: []string{},
+	}
+	if option != nil {
+		extractor.option = *option
+	}
+
+	if page != nil && !extractor.option.SkipProperties {
+		properties := page.Properties()
+		sort.Slice(properties, func(i, j int) bool {
+			return properties[i].Name() < properties[j].Name()
+		})
+
+		lines := []string{}
+		for _, property := range properties {
+			if text := PropertyPlainText(property); len(text) > 0 {
+				lines = append(lines, property.Name()+": "+text)
+			}
+		}
+		if len(lines) > 0 {
+			extractor.chunks = append(extractor.chunks, TextChunk{
+				Section:  []string{},
+				BlockIDs: []string{page.ID()},
+				Text:     strings.Join(lines, "\n"),
+			})
+		}
+	}
+
+	extractor.walk(nodes, 0)
+	extractor.flush()
+
+	return extractor.chunks
+}
+
+func (extractor *textExtractor) flush() {
+	if extractor.current != nil && (len(extractor.current.Text) > 0 || extractor.heading) {
+		extractor.chunks = append(extractor.chunks, *extractor.current)
+	}
+	extractor.current = nil
+	extractor.heading = false
+}
+
+func (extractor *textExtractor) add(BlockID, Text string) {
+	if extractor.current != nil && extractor.option.MaxChunkSize > 0 && len(extractor.current.Text) > 0 &&
+		len(extractor.current.Text)+len(Text) > extractor.option.MaxChunkSize {
+		extractor.flush()
+	}
+
+	if extractor.current == nil {
+		section := make([]string, len(extractor.section))
+		copy(section, extractor.section)
+
+		extractor.current = &TextChunk{Section: section, BlockIDs: []string{}}
+	}
+
+	extractor.current.BlockIDs = append(extractor.current.BlockIDs, BlockID)
+	if len(Text) == 0 {
+		return
+	}
+	if len(extractor.current.Text) > 0 {
+		extractor.current.Text += "\n"
+	}
+	extractor.current.Text += Text
+}
+
+func (extractor *textExtractor) walk(nodes []*BlockNode, level int) {
+	for _, node := range nodes {
+		block := node.Block
+		text := BlockPlainText(block)
+
+		depth := 0
+		switch block.Type() {
+		case TypeBlockHeading1:
+			depth = 1
+		case TypeBlockHeading2:
+			depth = 2
+		case TypeBlockHeading3:
+			depth = 3
+		}
+
+		if depth > 0 {
+			extractor.flush()
+
+			section := []string{}
+			if depth-1 <= len(extractor.section) {
+				section = append(section, extractor.section[:depth-1]...)
+			} else {
+				section = append(section, extractor.section...)
+			}
+			extractor.section = append(section, text)
+			extractor.add(block.ID(), "")
+			extractor.heading = true
+			continue
+		}
+
+		switch block.Type() {
+		case TypeBlockTodo:
+			if todo, ok := block.(*BlockTodo); ok && todo.IsChecked() {
+				text = "[x] " + text
+			} else {
+				text = "[ ] " + text
+			}
+		}
+		if len(text) > 0 && level > 0 {
+			text = strings.Repeat("  ", level) + text
+		}
+
+		extractor.add(block.ID(), text)
+		extractor.walk(node.Children, level+1)
+	}
+}
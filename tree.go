@@ -0,0 +1,213 @@
+package notion
+
+import (
+	"fmt"
+	"sync"
+)
+
+type BlockNode struct {
+	Block    Block
+	Depth    int
+	Children []*BlockNode
+}
+
+// Walk visit node and its descendants in document order, stop descending when fn returns false
+func (node *BlockNode) Walk(fn func(node *BlockNode) bool) {
+	if !fn(node) {
+		return
+	}
+
+	for _, child := range node.Children {
+		child.Walk(fn)
+	}
+}
+
+// WalkTree call Walk for every root node
+func WalkTree(nodes []*BlockNode, fn func(node *BlockNode) bool) {
+	for _, node := range nodes {
+		node.Walk(fn)
+	}
+}
+
+type FetchTreeProgress struct {
+	BlockID   string
+	Depth     int
+	Fetched   int
+	Completed int
+	Pending   int
+}
+
+type FetchTreeOption struct {
+	//MaxDepth limit depth of descent, 0 is unlimited. root children are depth 1
+	MaxDepth int
+	//Concurrency number of concurrent requests, default 4
+	Concurrency int
+	//PageSize page_size of each RetrieveBlockChildren request
+	PageSize int
+	//SkipTypes block types not to descend into (e.g. TypeBlockChildPage)
+	SkipTypes []string
+	//Skip custom rule not to descend into block, may be called concurrently
+	Skip func(block Block) bool
+	//Progress called after children of each block are fetched, may be called concurrently
+	Progress func(progress FetchTreeProgress)
+}
+
+func (option *FetchTreeOption) descend(node *BlockNode) bool {
+	block := node.Block
+	if !block.HasChildren() {
+		return false
+	}
+
+	if option.MaxDepth > 0 && node.Depth >= option.MaxDepth {
+		return false
+	}
+
+	for _, t := range option.SkipTypes {
+		if block.Type() == t {
+			return false
+		}
+	}
+
+	if option.Skip != nil && option.Skip(block) {
+		return false
+	}
+
+	return true
+}
+
+// RetrieveAllBlockChildren retrieve children of block following next_cursor until has_more is false
+func (notion *Notion) RetrieveAllBlockChildren(BlockID string, PageSize int) ([]Block, error) {
+	if notion.invalid() {
+		return nil, fmt.Errorf("Nil pointer API Implementation")
+	}
+
+	blocks := []Block{}
+	pagination := &PaginationRequest{PageSize: PageSize}
+
+	for {
+		resp, err := notion.api.RetrieveBlockChildren(BlockID, pagination)
+		if err != nil {
+			return nil, err
+		}
+
+		list, err := resp.Blocks()
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, list...)
+
+		if !resp.HasMore || len(resp.NextCursor) == 0 {
+			break
+		}
+		pagination.StartCursor = resp.NextCursor
+	}
+
+	return blocks, nil
+}
+
+type treeFetcher struct {
+	notion *Notion
+	option FetchTreeOption
+
+	sem chan struct{}
+	wg  sync.WaitGroup
+
+	mutex     sync.Mutex
+	err       error
+	fetched   int
+	completed int
+	pending   int
+}
+
+// FetchTree retrieve all blocks of page as nested tree. children are fetched in parallel within Concurrency
+func (notion *Notion) FetchTree(PageID string, Option *FetchTreeOption) ([]*BlockNode, error) {
+	if notion.invalid() {
+		return nil, fmt.Errorf("Nil pointer API Implementation")
+	}
+
+	fetcher := &treeFetcher{notion: notion}
+	if Option != nil {
+		fetcher.option = *Option
+	}
+	if fetcher.option.Concurrency <= 0 {
+		fetcher.option.Concurrency = 4
+	}
+	fetcher.sem = make(chan struct{}, fetcher.option.Concurrency)
+
+	root := &BlockNode{Depth: 0}
+
+	fetcher.pending = 1
+	fetcher.wg.Add(1)
+	fetcher.fetch(PageID, root)
+	fetcher.wg.Wait()
+
+	if fetcher.err != nil {
+		return nil, fetcher.err
+	}
+
+	return root.Children, nil
+}
+
+func (fetcher *treeFetcher) failed() bool {
+	fetcher.mutex.Lock()
+	defer fetcher.mutex.Unlock()
+
+	return fetcher.err != nil
+}
+
+func (fetcher *treeFetcher) fetch(BlockID string, parent *BlockNode) {
+	defer fetcher.wg.Done()
+
+	if fetcher.failed() {
+		return
+	}
+
+	fetcher.sem <- struct{}{}
+	blocks, err := fetcher.notion.RetrieveAllBlockChildren(BlockID, fetcher.option.PageSize)
+	<-fetcher.sem
+
+	if err != nil {
+		fetcher.mutex.Lock()
+		fetcher.pending--
+		if fetcher.err == nil {
+			fetcher.err = fmt.Errorf("block '%s': %v", BlockID, err)
+		}
+		fetcher.mutex.Unlock()
+		return
+	}
+
+	parent.Children = make([]*BlockNode, 0, len(blocks))
+	descend := []*BlockNode{}
+	for _, block := range blocks {
+		node := &BlockNode{Block: block, Depth: parent.Depth + 1}
+		parent.Children = append(parent.Children, node)
+
+		if fetcher.option.descend(node) {
+			descend = append(descend, node)
+		}
+	}
+
+	fetcher.mutex.Lock()
+	fetcher.pending--
+	fetcher.fetched += len(blocks)
+	fetcher.completed++
+	fetcher.pending += len(descend)
+	progress := FetchTreeProgress{
+		BlockID:   BlockID,
+		Depth:     parent.Depth,
+		Fetched:   fetcher.fetched,
+		Completed: fetcher.completed,
+		Pending:   fetcher.pending,
+	}
+	fetcher.mutex.Unlock()
+
+	//callback is called without lock, so it can be slow or call into Notion without blocking other workers
+	if fetcher.option.Progress != nil {
+		fetcher.option.Progress(progress)
+	}
+
+	for _, node := range descend {
+		fetcher.wg.Add(1)
+		go fetcher.fetch(node.Block.ID(), node)
+	}
+}
@@ -3,7 +3,7 @@ package notion
 import "fmt"
 
 const (
-	UserTypePerson = "pserson"
+	UserTypePerson = "person"
 	UserTypeBot    = "bot"
 )
 
@@ -71,6 +71,42 @@ func (user *User) Email() string {
 	return ""
 }
 
+type UserPerson struct {
+	Email string `json:"email"`
+}
+
+type UserBot struct {
+	Owner JSON `json:"owner,omitempty"`
+}
+
+// Person return details of person, nil if user is not person
+func (user *User) Person() *UserPerson {
+	if !user.IsPerson() {
+		return nil
+	}
+
+	person := &UserPerson{}
+	if j, ok := user.JSON.GetJSON("person"); ok {
+		j.Unmarshal(person)
+	}
+
+	return person
+}
+
+// Bot return details of bot, nil if user is not bot
+func (user *User) Bot() *UserBot {
+	if !user.IsBot() {
+		return nil
+	}
+
+	bot := &UserBot{}
+	if j, ok := user.JSON.GetJSON("bot"); ok {
+		j.Unmarshal(bot)
+	}
+
+	return bot
+}
+
 func (user *User) String() string {
 	return user.JSON.String()
 }
@@ -0,0 +1,483 @@
+package notion
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	EventPageCreated = "page.created"
+	//EventPageUpdated properties of page are changed, see Event.Changed
+	EventPageUpdated = "page.updated"
+	//EventPageArchived page is archived, deleted or no longer shared with integration
+	EventPageArchived = "page.archived"
+	//EventPageContentChanged blocks of page are changed
+	EventPageContentChanged = "page.content_changed"
+)
+
+// Event change of page found by Watcher
+type Event struct {
+	Type   string `json:"type"`
+	PageID string `json:"page_id"`
+	//Parent parent object of page, e.g. database which page is row of
+	Parent         JSON   `json:"parent,omitempty"`
+	LastEditedTime string `json:"last_edited_time"`
+	//Changed names of added, removed or changed properties of EventPageUpdated.
+	//empty when page was not watched before, so previous values are unknown
+	Changed []string `json:"changed,omitempty"`
+	//Page object of page, nil for page which can not be retrieved
+	Page JSON `json:"page,omitempty"`
+}
+
+// WatchState progress of Watcher, saved and passed to next Watcher to continue after restart
+type WatchState struct {
+	//Cursors last_edited_time which each source is polled up to, source is "search" or ID of database
+	Cursors map[string]string       `json:"cursors"`
+	Pages   map[string]*WatchedPage `json:"pages"`
+	//Scanned time of last scan of archived pages
+	Scanned string `json:"scanned,omitempty"`
+}
+
+type WatchedPage struct {
+	//Source search or ID of database which page is found by
+	Source string `json:"source"`
+	//Parent parent of page, set to Event.Parent of archived page which can no longer be retrieved
+	Parent         JSON   `json:"parent,omitempty"`
+	LastEditedTime string `json:"last_edited_time"`
+	//Properties hash of value of each property
+	Properties map[string]string `json:"properties"`
+	//Blocks hash of blocks, set when WatchOption.Blocks is true
+	Blocks string `json:"blocks,omitempty"`
+}
+
+type WatchOption struct {
+	//Databases IDs of databases to query. when empty, all pages shared with integration are found by Search
+	Databases []string
+	//Interval between polls, default 1 minute
+	Interval time.Duration
+	//Blocks fetch blocks of edited pages to find changed content. otherwise EventPageContentChanged is sent
+	//when page is edited without change of properties
+	Blocks bool
+	//ArchiveScan interval of listing all pages to find archived pages, which are not returned by Search and QueryDatabase.
+	//default 1 hour, negative disables it
+	ArchiveScan time.Duration
+	//State state of previous Watcher. when nil, first poll records present time and sends no event
+	State *WatchState
+	//SaveState called after each poll, e.g. to write state to file
+	SaveState func(state *WatchState) error
+	//OnError called by Run when poll or SaveState fails. state of failed poll is not changed,
+	//so its changes are found by next poll
+	OnError func(err error)
+}
+
+// Watcher poll pages edited since last poll and send events of their changes. API of this version has no webhooks
+type Watcher struct {
+	notion *Notion
+	option WatchOption
+	state  *WatchState
+}
+
+func (notion *Notion) NewWatcher(Option *WatchOption) *Watcher {
+	watcher := &Watcher{notion: notion}
+	if Option != nil {
+		watcher.option = *Option
+	}
+	if watcher.option.Interval <= 0 {
+		watcher.option.Interval = time.Minute
+	}
+	if watcher.option.ArchiveScan == 0 {
+		watcher.option.ArchiveScan = time.Hour
+	}
+
+	watcher.state = watcher.option.State
+	if watcher.state == nil {
+		watcher.state = &WatchState{}
+	}
+	if watcher.state.Cursors == nil {
+		watcher.state.Cursors = map[string]string{}
+	}
+	if watcher.state.Pages == nil {
+		watcher.state.Pages = map[string]*WatchedPage{}
+	}
+
+	return watcher
+}
+
+// State return current state of watcher
+func (watcher *Watcher) State() *WatchState {
+	return watcher.state
+}
+
+// Run poll every Interval until ctx is done or Handler returns error. state is saved after events of poll are handled.
+// failed poll is reported to OnError and retried at next interval
+func (watcher *Watcher) Run(ctx context.Context, Handler func(event Event) error) error {
+	for {
+		events, err := watcher.pollContext(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			watcher.report(err)
+		} else {
+			for _, event := range events {
+				if err := Handler(event); err != nil {
+					return err
+				}
+			}
+
+			if watcher.option.SaveState != nil {
+				if err := watcher.option.SaveState(watcher.state); err != nil {
+					watcher.report(err)
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(watcher.option.Interval):
+		}
+	}
+}
+
+func (watcher *Watcher) report(err error) {
+	if watcher.option.OnError != nil {
+		watcher.option.OnError(err)
+	}
+}
+
+// Events run watcher and send events to channel, channel and errs are closed when ctx is done.
+// poll errors are reported to OnError
+func (watcher *Watcher) Events(ctx context.Context) (<-chan Event, <-chan error) {
+	events := make(chan Event)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(events)
+
+		err := watcher.Run(ctx, func(event Event) error {
+			select {
+			case events <- event:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+			errs <- err
+		}
+		close(errs)
+	}()
+
+	return events, errs
+}
+
+// Poll find changes since last poll once. state is not changed when poll fails, so it can be retried
+func (watcher *Watcher) Poll() ([]Event, error) {
+	return watcher.pollContext(context.Background())
+}
+
+// pollContext poll and stop between requests when ctx is done
+func (watcher *Watcher) pollContext(ctx context.Context) ([]Event, error) {
+	saved := *watcher.state
+	saved.Cursors = map[string]string{}
+	for source, cursor := range watcher.state.Cursors {
+		saved.Cursors[source] = cursor
+	}
+	saved.Pages = map[string]*WatchedPage{}
+	for id, page := range watcher.state.Pages {
+		saved.Pages[id] = page
+	}
+
+	events, err := watcher.pollSources(ctx)
+	if err != nil {
+		*watcher.state = saved
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (watcher *Watcher) pollSources(ctx context.Context) ([]Event, error) {
+	events := []Event{}
+
+	if len(watcher.option.Databases) == 0 {
+		sort := &Sort{Timestamp: LastEditedTime, Direction: Descending}
+		if err := watcher.poll(ctx, "search", func(pagination *PaginationRequest) (*PaginationResponse, error) {
+			return watcher.notion.Search("", pagination, ObjectPage, sort)
+		}, &events); err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range watcher.option.Databases {
+		sorts := []Sort{{Timestamp: LastEditedTime, Direction: Descending}}
+		if err := watcher.poll(ctx, id, func(pagination *PaginationRequest) (*PaginationResponse, error) {
+			return watcher.notion.QueryDatabase(id, pagination, nil, sorts)
+		}, &events); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := watcher.scanArchived(ctx, &events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// poll read pages of source in descending order of last_edited_time down to cursor.
+// last_edited_time is rounded to minute, so pages of the minute of cursor are checked again
+func (watcher *Watcher) poll(ctx context.Context, source string, fetch func(pagination *PaginationRequest) (*PaginationResponse, error), events *[]Event) error {
+	cursor, initialized := watcher.state.Cursors[source]
+	var since time.Time
+	if initialized {
+		t, err := ParseTime(cursor)
+		if err != nil {
+			return fmt.Errorf("cursor of '%s': %w", source, err)
+		}
+		since = t
+	}
+
+	newest := since
+	pagination := &PaginationRequest{PageSize: 100}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		resp, err := fetch(pagination)
+		if err != nil {
+			return fmt.Errorf("%s: %w", source, err)
+		}
+
+		pages, err := resp.Pages()
+		if err != nil {
+			return err
+		}
+
+		for i := range pages {
+			page := &pages[i]
+			edited, err := ParseTime(page.LastEditedTime())
+			if err != nil {
+				return fmt.Errorf("page '%s': %w", page.ID(), err)
+			}
+			if edited.Before(since) {
+				watcher.state.Cursors[source] = TimeFormat(newest)
+				return nil
+			}
+			if edited.After(newest) {
+				newest = edited
+			}
+
+			if !initialized {
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := watcher.check(source, page, since, events); err != nil {
+				return err
+			}
+		}
+
+		if !initialized || !resp.HasMore || len(resp.NextCursor) == 0 {
+			break
+		}
+		pagination.StartCursor = resp.NextCursor
+	}
+
+	if !initialized && newest.IsZero() {
+		newest = time.Now().UTC()
+	}
+	watcher.state.Cursors[source] = TimeFormat(newest)
+
+	return nil
+}
+
+// check compare page with its watched state
+func (watcher *Watcher) check(source string, page *Page, since time.Time, events *[]Event) error {
+	event := Event{
+		PageID:         page.ID(),
+		Parent:         pageParent(page),
+		LastEditedTime: page.LastEditedTime(),
+		Page:           page.JSON,
+	}
+
+	watched, ok := watcher.state.Pages[page.ID()]
+	if page.Archived() {
+		if ok {
+			delete(watcher.state.Pages, page.ID())
+			event.Type = EventPageArchived
+			*events = append(*events, event)
+		}
+		return nil
+	}
+
+	current := &WatchedPage{
+		Source:         source,
+		Parent:         pageParent(page),
+		LastEditedTime: page.LastEditedTime(),
+		Properties:     propertyHashes(page),
+	}
+	if watcher.option.Blocks {
+		nodes, err := watcher.notion.FetchTree(page.ID(), &FetchTreeOption{SkipTypes: []string{TypeBlockChildPage, TypeBlockChildDatabase}})
+		if err != nil {
+			return fmt.Errorf("page '%s': %w", page.ID(), err)
+		}
+		current.Blocks = hashJSON(treeJSON(nodes))
+	}
+	watcher.state.Pages[page.ID()] = current
+
+	if !ok {
+		event.Type = EventPageUpdated
+		if created, err := ParseTime(page.CreatedTime()); err == nil && !created.Before(since) {
+			event.Type = EventPageCreated
+		}
+		*events = append(*events, event)
+		return nil
+	}
+
+	event.Changed = changedProperties(watched.Properties, current.Properties)
+	if len(event.Changed) > 0 {
+		event.Type = EventPageUpdated
+		*events = append(*events, event)
+	}
+
+	contentChanged := watched.LastEditedTime != current.LastEditedTime && len(event.Changed) == 0
+	if watcher.option.Blocks {
+		contentChanged = watched.Blocks != current.Blocks
+	}
+	if contentChanged {
+		content := event
+		content.Type = EventPageContentChanged
+		content.Changed = nil
+		*events = append(*events, content)
+	}
+
+	return nil
+}
+
+// scanArchived list all pages of sources, watched pages which are not listed are retrieved to check they are archived
+func (watcher *Watcher) scanArchived(ctx context.Context, events *[]Event) error {
+	if watcher.option.ArchiveScan < 0 {
+		return nil
+	}
+	if scanned, err := ParseTime(watcher.state.Scanned); err == nil && time.Since(scanned) < watcher.option.ArchiveScan {
+		return nil
+	}
+	if len(watcher.state.Scanned) == 0 && len(watcher.state.Pages) == 0 {
+		watcher.state.Scanned = TimeFormat(time.Now().UTC())
+		return nil
+	}
+
+	listed := map[string]bool{}
+	sources := watcher.option.Databases
+	if len(sources) == 0 {
+		sources = []string{"search"}
+	}
+	for _, source := range sources {
+		results, err := listAll(func(pagination *PaginationRequest) (*PaginationResponse, error) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if source == "search" {
+				return watcher.notion.Search("", pagination, ObjectPage, nil)
+			}
+			return watcher.notion.QueryDatabase(source, pagination, nil, nil)
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", source, err)
+		}
+		for _, j := range results {
+			listed[j.GetString("id")] = true
+		}
+	}
+
+	ids := []string{}
+	for id := range watcher.state.Pages {
+		if !listed[id] {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		event := Event{Type: EventPageArchived, PageID: id, Parent: watcher.state.Pages[id].Parent}
+
+		page, err := watcher.notion.RetrievePage(id)
+		if err != nil {
+			var status interface{ StatusCode() int }
+			if !errors.As(err, &status) || status.StatusCode() != 404 {
+				return fmt.Errorf("page '%s': %w", id, err)
+			}
+		} else {
+			if !page.Archived() {
+				continue
+			}
+			event.Parent = pageParent(page)
+			event.LastEditedTime = page.LastEditedTime()
+			event.Page = page.JSON
+		}
+
+		delete(watcher.state.Pages, id)
+		*events = append(*events, event)
+	}
+
+	watcher.state.Scanned = TimeFormat(time.Now().UTC())
+
+	return nil
+}
+
+func pageParent(page *Page) JSON {
+	parent, _ := page.JSON.GetJSON("parent")
+
+	return parent
+}
+
+// propertyHashes hash of value of each property, last edited time and user which change on every edit are skipped
+func propertyHashes(page *Page) map[string]string {
+	hashes := map[string]string{}
+
+	for _, property := range page.Properties() {
+		switch property.Type() {
+		case TypePropertyLastEditedTime, TypePropertyLastEditedBy:
+			continue
+		}
+		hashes[property.Name()] = hashJSON(property.Json())
+	}
+
+	return hashes
+}
+
+func changedProperties(previous, current map[string]string) []string {
+	changed := []string{}
+
+	for name, hash := range current {
+		if previous[name] != hash {
+			changed = append(changed, name)
+		}
+	}
+	for name := range previous {
+		if _, ok := current[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+
+	return changed
+}
+
+func hashJSON(v interface{}) string {
+	j := JSON{"v": v}
+	sum := sha256.Sum256([]byte(j.String()))
+
+	return hex.EncodeToString(sum[:8])
+}